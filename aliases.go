@@ -0,0 +1,346 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+)
+
+// maxMacroDepth limits how deeply macros may run other macros, so a macro
+// that runs itself fails instead of looping forever
+const maxMacroDepth = 10
+
+var commandNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+var macroParamPattern = regexp.MustCompile(`\$([1-9])`)
+
+// userCommandsConfig contains the aliases and macros defined by the user,
+// mapping each alias to the command it runs and each macro to its body
+type userCommandsConfig struct {
+	Aliases map[string]string `json:"aliases"`
+	Macros  map[string]string `json:"macros"`
+}
+
+// userCommands are loaded at startup and added to every command table built
+// by initializeCliCommands
+var userCommands = userCommandsConfig{Aliases: map[string]string{}, Macros: map[string]string{}}
+
+// macroDepth is the number of macros currently running
+var macroDepth int
+
+// userCommandsFilePath returns the path of the file aliases and macros are
+// kept in
+func userCommandsFilePath() string {
+	return configFilePath("commands.json")
+}
+
+// loadUserCommands reads the aliases and macros from the file.  A missing
+// file is not an error.
+func loadUserCommands(path string) (userCommandsConfig, error) {
+	config := userCommandsConfig{Aliases: map[string]string{}, Macros: map[string]string{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if config.Aliases == nil {
+		config.Aliases = map[string]string{}
+	}
+	if config.Macros == nil {
+		config.Macros = map[string]string{}
+	}
+	return config, nil
+}
+
+// save writes the aliases and macros to the file, creating its directory
+// if needed
+func (c userCommandsConfig) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// addUserCommands adds the user's macros to the command table and their
+// aliases to the commands they run.  Aliases of commands that no longer
+// exist are ignored.
+func addUserCommands(commands map[string]cliCommand) {
+	for _, name := range sortedMapKeys(userCommands.Macros) {
+		if _, exists := commands[name]; !exists {
+			commands[name] = newMacroCommand(commands, name, userCommands.Macros[name])
+		}
+	}
+	for _, name := range sortedMapKeys(userCommands.Aliases) {
+		addAlias(commands, name, userCommands.Aliases[name])
+	}
+}
+
+func addAlias(commands map[string]cliCommand, name string, target string) {
+	command, exists := findCommand(commands, target)
+	if !exists {
+		return
+	}
+	command.aliases = append(command.aliases[:len(command.aliases):len(command.aliases)], name)
+	commands[command.name] = command
+}
+
+func removeAlias(commands map[string]cliCommand, name string) {
+	command, exists := findCommand(commands, name)
+	if !exists {
+		return
+	}
+
+	aliases := make([]string, 0, len(command.aliases))
+	for _, alias := range command.aliases {
+		if alias != name {
+			aliases = append(aliases, alias)
+		}
+	}
+	command.aliases = aliases
+	commands[command.name] = command
+}
+
+func commandAlias(commands map[string]cliCommand, args cli.Args) error {
+	name := args.String("name")
+	if args.Bool("delete") {
+		if _, exists := userCommands.Aliases[name]; !exists {
+			return fmt.Errorf("%q is not an alias", name)
+		}
+		delete(userCommands.Aliases, name)
+		removeAlias(commands, name)
+		return userCommands.save(userCommandsFilePath())
+	}
+
+	if !args.IsSet("command") {
+		if name != "" {
+			target, exists := userCommands.Aliases[name]
+			if !exists {
+				return fmt.Errorf("%q is not an alias", name)
+			}
+			fmt.Printf("%s -> %s\n", name, target)
+			return nil
+		}
+		if len(userCommands.Aliases) == 0 {
+			fmt.Println("No aliases defined")
+		}
+		for _, alias := range sortedMapKeys(userCommands.Aliases) {
+			fmt.Printf("%s -> %s\n", alias, userCommands.Aliases[alias])
+		}
+		return nil
+	}
+
+	if err := checkNewCommandName(commands, name); err != nil {
+		return err
+	}
+	target, exists := findCommand(commands, args.String("command"))
+	if !exists {
+		return unknownCommandError(commands, args.String("command"))
+	}
+
+	if previous, exists := userCommands.Aliases[name]; exists {
+		removeAlias(commands, name)
+		fmt.Printf("Replacing alias %s -> %s\n", name, previous)
+	}
+	userCommands.Aliases[name] = target.name
+	addAlias(commands, name, target.name)
+	return userCommands.save(userCommandsFilePath())
+}
+
+func commandMacro(commands map[string]cliCommand, args cli.Args) error {
+	name := args.String("name")
+	if args.Bool("delete") {
+		if _, exists := userCommands.Macros[name]; !exists {
+			return fmt.Errorf("%q is not a macro", name)
+		}
+		delete(userCommands.Macros, name)
+		delete(commands, name)
+		for _, alias := range sortedMapKeys(userCommands.Aliases) {
+			if userCommands.Aliases[alias] == name {
+				delete(userCommands.Aliases, alias)
+				fmt.Printf("Deleted alias %s -> %s\n", alias, name)
+			}
+		}
+		return userCommands.save(userCommandsFilePath())
+	}
+
+	body := args.List("body")
+	if len(body) > 0 && body[0] == "=" {
+		body = body[1:]
+	}
+	if len(body) == 0 {
+		if name != "" {
+			macro, exists := userCommands.Macros[name]
+			if !exists {
+				return fmt.Errorf("%q is not a macro", name)
+			}
+			fmt.Printf("%s = %s\n", name, macro)
+			return nil
+		}
+		if len(userCommands.Macros) == 0 {
+			fmt.Println("No macros defined")
+		}
+		for _, macro := range sortedMapKeys(userCommands.Macros) {
+			fmt.Printf("%s = %s\n", macro, userCommands.Macros[macro])
+		}
+		return nil
+	}
+
+	if _, exists := userCommands.Macros[name]; !exists {
+		if err := checkNewCommandName(commands, name); err != nil {
+			return err
+		}
+	}
+	userCommands.Macros[name] = macroBody(body)
+	commands[name] = newMacroCommand(commands, name, userCommands.Macros[name])
+	return userCommands.save(userCommandsFilePath())
+}
+
+// macroBody joins the arguments of a macro definition back into a command
+// line, quoting them so they read back the same when the macro runs
+func macroBody(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == cli.Separator {
+			quoted = append(quoted, ";")
+			continue
+		}
+		quoted = append(quoted, cli.Quote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// checkNewCommandName returns an error if the name cannot be used for a new
+// alias or macro
+func checkNewCommandName(commands map[string]cliCommand, name string) error {
+	if !commandNamePattern.MatchString(name) {
+		return fmt.Errorf("%q is not a valid name, use lowercase letters, digits and hyphens", name)
+	}
+	if _, isAlias := userCommands.Aliases[name]; isAlias {
+		return nil
+	}
+	if command, exists := findCommand(commands, name); exists {
+		return fmt.Errorf("%q is already used by the %s command", name, command.name)
+	}
+	return nil
+}
+
+// newMacroCommand returns a command that runs the semicolon separated
+// commands of the macro body, replacing $1 to $9 with its arguments and $@
+// with all of them
+func newMacroCommand(commands map[string]cliCommand, name string, body string) cliCommand {
+	return cliCommand{
+		name:        name,
+		description: "Macro: " + body,
+		spec: cli.Spec{
+			Args: []cli.Arg{
+				{Name: "args", Variadic: true, CaseSensitive: true, Usage: "the values of $1, $2, ... in the macro"},
+			},
+			FlagsFirst: true,
+		},
+		callback: func(args cli.Args) error {
+			return runMacro(commands, name, body, args.List("args"))
+		},
+	}
+}
+
+func runMacro(commands map[string]cliCommand, name string, body string, params []string) error {
+	if macroDepth >= maxMacroDepth {
+		return fmt.Errorf("macro %s: macros are nested too deeply", name)
+	}
+	macroDepth++
+	defer func() { macroDepth-- }()
+
+	tokens, err := cli.Tokenize(body)
+	if err != nil {
+		return fmt.Errorf("macro %s: %w", name, err)
+	}
+	for _, line := range splitCommands(tokens) {
+		line, err = expandMacroParams(line, params)
+		if err != nil {
+			return fmt.Errorf("macro %s: %w", name, err)
+		}
+		if err := executeCommand(commands, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitCommands splits tokens into the commands separated by semicolons,
+// skipping empty ones
+func splitCommands(tokens []string) [][]string {
+	var lines [][]string
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && tokens[i] != cli.Separator {
+			continue
+		}
+		if i > start {
+			lines = append(lines, tokens[start:i])
+		}
+		start = i + 1
+	}
+	return lines
+}
+
+// expandMacroParams replaces the parameters in the tokens with the macro's
+// arguments
+func expandMacroParams(tokens []string, params []string) ([]string, error) {
+	expanded := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if token == "$@" {
+			expanded = append(expanded, params...)
+			continue
+		}
+
+		var missing error
+		token = macroParamPattern.ReplaceAllStringFunc(token, func(param string) string {
+			index, _ := strconv.Atoi(param[1:])
+			if index > len(params) {
+				missing = fmt.Errorf("%s is not set, %d arguments given", param, len(params))
+				return ""
+			}
+			return params[index-1]
+		})
+		if missing != nil {
+			return nil, missing
+		}
+		expanded = append(expanded, token)
+	}
+	return expanded, nil
+}
+
+// completeAlias completes command names for the command an alias runs
+func completeAlias(argIndex int) []string {
+	if argIndex != 1 {
+		return nil
+	}
+	return commandNames(initializeCliCommands())
+}
+
+func sortedMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
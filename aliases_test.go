@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMacros(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	userCommands = userCommandsConfig{Aliases: map[string]string{}, Macros: map[string]string{}}
+	commands := initializeCliCommands()
+
+	cases := []struct {
+		line      string
+		expectErr bool
+	}{
+		{line: "macro fire = pokedex --type fire; pokedex --sort $1 --desc"},
+		{line: "fire bst"},
+		{line: "macro fires = pokedex $@"},
+		{line: "fires --type fire --sort bst"},
+		{line: "macro semi = pokedex --type ';'"},
+		{line: "semi"},
+		{line: "pokedex --type fire; pokedex", expectErr: true},
+		{line: "alias f fire"},
+		{line: "macro --delete fire"},
+		{line: "f", expectErr: true},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			err := executeLine(commands, c.line)
+			if (err != nil) != c.expectErr {
+				t.Errorf("%s: expected an error %v, actual %v", c.line, c.expectErr, err)
+			}
+		})
+	}
+
+	if body := userCommands.Macros["semi"]; body != "pokedex --type ';'" {
+		t.Errorf("expected the quoted semicolon to be kept, actual %s", body)
+	}
+	if body := userCommands.Macros["fires"]; body != "pokedex $@" {
+		t.Errorf("expected the body as typed, actual %s", body)
+	}
+	if _, exists := userCommands.Aliases["f"]; exists {
+		t.Errorf("expected the alias of the deleted macro to be deleted")
+	}
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rkanagy/pokedexcli/internal/battle"
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/lineedit"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+	"github.com/rkanagy/pokedexcli/internal/trainer"
+)
+
+const defaultBattleAI = "random"
+const maxBattleMoves = 4
+
+func commandBattle(args cli.Args) error {
+	strategy, err := battle.NewStrategy(args.String("ai"))
+	if err != nil {
+		return err
+	}
+
+	// the lines after a battle in a script are commands, not moves
+	chooseMove := promptBattleMove
+	if args.IsSet("moves") {
+		chooseMove, err = givenBattleMoves(args.String("moves"))
+		if err != nil {
+			return err
+		}
+	} else if !lineEditor.IsTerminal() {
+		return errors.New("Give the moves of a battle with --moves when not reading from a terminal")
+	}
+
+	mine, err := currentTrainer.Find(args.String("mine"))
+	if err != nil {
+		return err
+	}
+
+	level := mine.Level
+	if args.IsSet("level") {
+		level = args.Int("level")
+		if level < 1 || level > 100 {
+			return errors.New("The level must be a number between 1 and 100")
+		}
+	}
+
+	seed := time.Now().UnixNano()
+	if args.IsSet("seed") {
+		seed = int64(args.Int("seed"))
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	var opponentSpecies []pokemon.Pokemon
+	var opponents []battle.Combatant
+	if opponentNames := args.String("opponent"); opponentNames == "" || opponentNames == "wild" {
+		wildLevel := 0
+		if args.IsSet("level") {
+			wildLevel = level
+		}
+		wildSpecies, wild, err := newWildCombatant(rng, wildLevel)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("A wild %s appeared!\n", wild.Name)
+		opponentSpecies = append(opponentSpecies, wildSpecies)
+		opponents = append(opponents, wild)
+	} else {
+		opponentSpecies, opponents, err = newOpponentTeam(strings.Split(opponentNames, ","), level)
+		if err != nil {
+			return err
+		}
+	}
+
+	player, err := newCombatant(mine.Species, mine.Level, mine.Stats())
+	if err != nil {
+		return err
+	}
+
+	b := battle.New(player, opponents, rng)
+	if err := runBattle(b, strategy, chooseMove); err != nil {
+		return err
+	}
+	if b.Winner() == b.Player {
+		rewardVictory(mine, opponentSpecies, opponents)
+	}
+
+	return nil
+}
+
+// rewardVictory gives the winning Pokemon experience and effort values for
+// every defeated opponent
+func rewardVictory(mine *trainer.CaughtPokemon, opponentSpecies []pokemon.Pokemon, opponents []battle.Combatant) {
+	experience := 0
+	for i, species := range opponentSpecies {
+		experience += species.BaseExperience * opponents[i].Level / 7
+		mine.GainEffort(species)
+	}
+
+	levels := mine.GainExperience(experience)
+	fmt.Printf("%s gained %d experience points!\n", mine.Name(), experience)
+	if levels > 0 {
+		fmt.Printf("%s grew to level %d!\n", mine.Name(), mine.Level)
+	}
+}
+
+// newOpponentTeam creates combatants for the given comma separated opponent
+// names at the given level, preferring caught Pokemon over ones fetched
+// from the API
+func newOpponentTeam(names []string, level int) ([]pokemon.Pokemon, []battle.Combatant, error) {
+	species := make([]pokemon.Pokemon, 0, len(names))
+	opponents := make([]battle.Combatant, 0, len(names))
+	for _, name := range names {
+		var opponent pokemon.Pokemon
+		if caught, err := currentTrainer.Find(name); err == nil {
+			opponent = caught.Species
+		} else {
+			opponent, err = pokemonAPI.GetPokemon(name)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		combatant, err := newCombatant(opponent, level, battle.CalculateStats(opponent.Stats, level))
+		if err != nil {
+			return nil, nil, err
+		}
+		species = append(species, opponent)
+		opponents = append(opponents, combatant)
+	}
+
+	return species, opponents, nil
+}
+
+// newWildCombatant creates a combatant for a random Pokemon encountered at
+// the current location area, at the level if it is not 0 and otherwise at a
+// level within its encounter range
+func newWildCombatant(rng *rand.Rand, level int) (pokemon.Pokemon, battle.Combatant, error) {
+	if currentTrainer.Location == "" {
+		return pokemon.Pokemon{}, battle.Combatant{}, errors.New("Travel to a location area first to encounter wild Pokemon")
+	}
+
+	location, err := pokemonAPI.GetLocationArea(currentTrainer.Location)
+	if err != nil {
+		return pokemon.Pokemon{}, battle.Combatant{}, err
+	}
+	if len(location.PokemonEncounters) == 0 {
+		return pokemon.Pokemon{}, battle.Combatant{}, fmt.Errorf("There are no wild Pokemon at %s", currentTrainer.Location)
+	}
+
+	encounter := location.PokemonEncounters[rng.Intn(len(location.PokemonEncounters))]
+	if level == 0 {
+		minLevel, maxLevel := encounterLevels(encounter)
+		if minLevel == 0 {
+			minLevel, maxLevel = defaultCatchLevel, defaultCatchLevel
+		}
+		level = minLevel + rng.Intn(maxLevel-minLevel+1)
+	}
+
+	wild, err := pokemonAPI.GetPokemon(encounter.Pokemon.Name)
+	if err != nil {
+		return pokemon.Pokemon{}, battle.Combatant{}, err
+	}
+
+	combatant, err := newCombatant(wild, level, battle.CalculateStats(wild.Stats, level))
+	return wild, combatant, err
+}
+
+// encounterLevels returns the lowest and highest level the Pokemon can be
+// encountered at, or 0 if the encounter has no level information
+func encounterLevels(encounter pokemon.PokemonEncounter) (int, int) {
+	minLevel, maxLevel := 0, 0
+	for _, versionDetail := range encounter.VersionDetails {
+		for _, detail := range versionDetail.EncounterDetails {
+			if minLevel == 0 || detail.MinLevel < minLevel {
+				minLevel = detail.MinLevel
+			}
+			if detail.MaxLevel > maxLevel {
+				maxLevel = detail.MaxLevel
+			}
+		}
+	}
+
+	return minLevel, maxLevel
+}
+
+// runBattle plays the battle, asking chooseMove for the player's move each
+// turn
+func runBattle(b *battle.Battle, strategy battle.Strategy, chooseMove func(player *battle.Combatant) (int, bool)) error {
+	fmt.Printf("%s (Lv. %d) vs. %s (Lv. %d)\n", b.Player.Name, b.Player.Level, b.Opponent.Name, b.Opponent.Level)
+
+	for !b.IsOver() {
+		displayBattleStatus(b)
+
+		playerMove, run := chooseMove(b.Player)
+		if run {
+			fmt.Println("Got away safely!")
+			return nil
+		}
+
+		log, err := b.PlayTurn(playerMove, strategy.ChooseAction(b))
+		if err != nil {
+			errorHandler(err)
+			continue
+		}
+		for _, entry := range log {
+			fmt.Println(entry)
+		}
+	}
+
+	fmt.Printf("%s won the battle after %d turns!\n", b.Winner().Name, b.Turn)
+	return nil
+}
+
+func displayBattleStatus(b *battle.Battle) {
+	fmt.Println()
+	fmt.Printf("%s: %d/%d HP\n", b.Player.Name, b.Player.HP, b.Player.Stats.HP)
+	fmt.Printf("%s: %d/%d HP\n", b.Opponent.Name, b.Opponent.HP, b.Opponent.Stats.HP)
+	fmt.Println("Moves:")
+	for i, move := range b.Player.Moves {
+		fmt.Printf("  %d. %s (%s, power %d, PP %d/%d)\n", i+1, move.Name, move.Type, move.Power, move.PP, move.MaxPP)
+	}
+	fmt.Println("  r. run")
+}
+
+// promptBattleMove asks the user for a move and returns its index, or
+// whether the user chose to run away
+func promptBattleMove(player *battle.Combatant) (int, bool) {
+	if !player.HasUsableMove() {
+		fmt.Printf("%s has no moves left!\n", player.Name)
+		return -1, false
+	}
+
+	for {
+		line, err := lineEditor.ReadLine("Choose a move > ")
+		if errors.Is(err, lineedit.ErrInterrupted) {
+			continue
+		}
+		if err != nil {
+			return 0, true
+		}
+
+		choice := cleanInput(line)
+		if choice == "r" || choice == "run" {
+			return 0, true
+		}
+
+		index, err := strconv.Atoi(choice)
+		if err == nil && index >= 1 && index <= len(player.Moves) {
+			return index - 1, false
+		}
+		fmt.Fprintln(os.Stderr, "invalid move")
+	}
+}
+
+// givenBattleMoves returns a move chooser making the comma separated moves,
+// numbers or run, in turn.  The player runs away once they are all made, or
+// at a move number the Pokemon does not have.
+func givenBattleMoves(list string) (func(player *battle.Combatant) (int, bool), error) {
+	moves := strings.Split(list, ",")
+	for _, move := range moves {
+		move = strings.TrimSpace(move)
+		if index, err := strconv.Atoi(move); (err != nil || index < 1) && move != "r" && move != "run" {
+			return nil, fmt.Errorf("invalid move %q, expected a move number or run", move)
+		}
+	}
+
+	return func(player *battle.Combatant) (int, bool) {
+		if len(moves) == 0 {
+			fmt.Println("No moves left to make.")
+			return 0, true
+		}
+		move := strings.TrimSpace(moves[0])
+		moves = moves[1:]
+
+		index, err := strconv.Atoi(move)
+		if err != nil {
+			return 0, true
+		}
+		if index > len(player.Moves) {
+			fmt.Fprintf(os.Stderr, "%s has no move %d\n", player.Name, index)
+			return 0, true
+		}
+		return index - 1, false
+	}, nil
+}
+
+// newCombatant creates a battle combatant with the given stats using the
+// most recent damaging moves the Pokemon learns by leveling up at or below
+// the given level
+func newCombatant(p pokemon.Pokemon, level int, stats battle.Stats) (battle.Combatant, error) {
+	moves := make([]battle.Move, 0, maxBattleMoves)
+	for _, name := range levelUpMoves(p, level) {
+		move, err := pokemonAPI.GetMove(name)
+		if err != nil {
+			return battle.Combatant{}, err
+		}
+
+		battleMove := battle.NewMove(move)
+		if battleMove.Class == battle.Status || battleMove.Power == 0 {
+			continue
+		}
+		moves = append(moves, battleMove)
+		if len(moves) == maxBattleMoves {
+			break
+		}
+	}
+
+	if len(moves) == 0 {
+		move, err := pokemonAPI.GetMove("tackle")
+		if err != nil {
+			return battle.Combatant{}, err
+		}
+		moves = append(moves, battle.NewMove(move))
+	}
+
+	return battle.NewCombatant(p, level, stats, moves), nil
+}
+
+// levelUpMoves returns the names of the moves learned by leveling up at or
+// below the given level, most recently learned first
+func levelUpMoves(p pokemon.Pokemon, level int) []string {
+	learnedAt := make(map[string]int)
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" || detail.LevelLearnedAt > level {
+				continue
+			}
+			if current, found := learnedAt[move.Move.Name]; !found || detail.LevelLearnedAt > current {
+				learnedAt[move.Move.Name] = detail.LevelLearnedAt
+			}
+		}
+	}
+
+	names := make([]string, 0, len(learnedAt))
+	for name := range learnedAt {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if learnedAt[names[i]] != learnedAt[names[j]] {
+			return learnedAt[names[i]] > learnedAt[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	return names
+}
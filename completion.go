@@ -0,0 +1,154 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/lineedit"
+)
+
+// seenLocationAreas contains the location areas listed by map, mapb and
+// location
+var seenLocationAreas = make(map[string]bool)
+
+// lastExploredPokemon contains the Pokemon found by the last explore
+var lastExploredPokemon []string
+
+// historyFilePath returns the path of the file the REPL history is kept in
+func historyFilePath() string {
+	return configFilePath("history")
+}
+
+// newCompleter returns a tab completer that completes command names, flag
+// names and, depending on the command, the values of its arguments.  line
+// is the text before the cursor.
+func newCompleter(commands map[string]cliCommand) lineedit.Completer {
+	return func(line string) (int, []string) {
+		start := strings.LastIndexAny(line, " \t") + 1
+		word := line[start:]
+
+		tokens, err := cli.Tokenize(line[:start])
+		if err != nil {
+			return 0, nil
+		}
+		if len(tokens) == 0 {
+			return start, withPrefix(commandNames(commands), word)
+		}
+
+		command, exists := findCommand(commands, cleanInput(tokens[0]))
+		if !exists {
+			return 0, nil
+		}
+		if strings.HasPrefix(word, "-") {
+			flags := make([]string, 0, len(command.spec.Flags))
+			for _, flag := range command.spec.Flags {
+				flags = append(flags, "--"+flag.Name)
+			}
+			return start, withPrefix(flags, word)
+		}
+		if command.complete == nil {
+			return 0, nil
+		}
+
+		return start, withPrefix(command.complete(command.spec.ArgIndex(tokens[1:])), word)
+	}
+}
+
+func commandNames(commands map[string]cliCommand) []string {
+	names := make([]string, 0, len(commands))
+	for _, command := range commands {
+		names = append(names, command.name)
+		names = append(names, command.aliases...)
+	}
+	return names
+}
+
+func withPrefix(words []string, prefix string) []string {
+	matches := []string{}
+	seen := make(map[string]bool)
+	for _, word := range words {
+		if strings.HasPrefix(word, prefix) && !seen[word] {
+			seen[word] = true
+			matches = append(matches, word)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// completeLocationAreas completes the location areas already seen by map
+func completeLocationAreas(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+
+	areas := make([]string, 0, len(seenLocationAreas))
+	for area := range seenLocationAreas {
+		areas = append(areas, area)
+	}
+	return areas
+}
+
+// completeExploredPokemon completes the Pokemon found by the last explore
+func completeExploredPokemon(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	return lastExploredPokemon
+}
+
+// completeCaughtPokemon completes the nicknames and names of caught Pokemon
+func completeCaughtPokemon(argIndex int) []string {
+	names := []string{}
+	for _, caught := range currentTrainer.All() {
+		if caught.Nickname != "" {
+			names = append(names, caught.Nickname)
+		}
+		names = append(names, caught.Species.Name)
+	}
+	return names
+}
+
+// completeFirstCaughtPokemon completes caught Pokemon for the first argument
+func completeFirstCaughtPokemon(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	return completeCaughtPokemon(argIndex)
+}
+
+// completeInspect completes caught Pokemon followed by Pokemon that have
+// only been seen
+func completeInspect(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	names := completeCaughtPokemon(argIndex)
+	for _, seen := range currentTrainer.Seen {
+		if !currentTrainer.HasCaught(seen.Name) {
+			names = append(names, seen.Name)
+		}
+	}
+	return names
+}
+
+// completeBattle completes your Pokemon followed by Pokemon from the last
+// explore as opponents
+func completeBattle(argIndex int) []string {
+	switch argIndex {
+	case 0:
+		return completeCaughtPokemon(argIndex)
+	case 1:
+		return append([]string{"wild"}, lastExploredPokemon...)
+	}
+	return nil
+}
+
+// completeCommandNames completes command names for help
+func completeCommandNames(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	return commandNames(initializeCliCommands())
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCompleter(t *testing.T) {
+	seenLocationAreas = map[string]bool{"forêt-de-jade": true, "forêt-noire": true, "viridian-forest-area": true}
+	complete := newCompleter(initializeCliCommands())
+
+	cases := []struct {
+		line          string
+		expectedStart int
+		expected      []string
+	}{
+		{line: "explore v", expectedStart: 8, expected: []string{"viridian-forest-area"}},
+		{line: "explore forê", expectedStart: 8, expected: []string{"forêt-de-jade", "forêt-noire"}},
+		{line: "explore forêt-n", expectedStart: 8, expected: []string{"forêt-noire"}},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			start, candidates := complete(c.line)
+			if start != c.expectedStart || !reflect.DeepEqual(candidates, c.expected) {
+				t.Errorf("%s: expected %q from %d, actual %q from %d", c.line, c.expected, c.expectedStart, candidates, start)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/fuzzy"
+)
+
+func commandHelp(args cli.Args) error {
+	commands := initializeCliCommands()
+	if args.IsSet("command") {
+		name := args.String("command")
+		command, exists := findCommand(commands, name)
+		if !exists {
+			return unknownCommandError(commands, name)
+		}
+
+		displayCommandHelp(command)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println("Welcome to the Pokedex!")
+	fmt.Println("Usage:")
+	fmt.Println()
+
+	sortedKeys := sortKeys(commands)
+	for _, key := range sortedKeys {
+		command := commands[key]
+		fmt.Printf("%s: %s\n", command.name, command.description)
+	}
+
+	fmt.Println()
+	fmt.Println("Use help <command> for detailed help on a command.")
+	fmt.Println()
+
+	return nil
+}
+
+// synopsis returns the usage line of the command, generated from its spec
+// unless an explicit usage string was given
+func (c cliCommand) synopsis() string {
+	if c.usage != "" {
+		return c.usage
+	}
+	return c.spec.Usage(c.name)
+}
+
+func displayCommandHelp(command cliCommand) {
+	fmt.Println()
+	fmt.Printf("%s - %s\n", command.name, command.description)
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Printf("  %s\n", command.synopsis())
+
+	if len(command.aliases) > 0 {
+		fmt.Println()
+		fmt.Printf("Aliases: %s\n", strings.Join(command.aliases, ", "))
+	}
+
+	if len(command.spec.Args) > 0 {
+		fmt.Println()
+		fmt.Println("Arguments:")
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, arg := range command.spec.Args {
+			fmt.Fprintf(writer, "  %s\t%s\n", arg.Name, arg.Usage)
+		}
+		writer.Flush()
+	}
+
+	if len(command.spec.Flags) > 0 {
+		fmt.Println()
+		fmt.Println("Flags:")
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, flag := range command.spec.Flags {
+			name := "--" + flag.Name
+			if flag.Short != "" {
+				name = "-" + flag.Short + ", " + name
+			}
+			usage := flag.Usage
+			if len(flag.Choices) > 0 {
+				usage += fmt.Sprintf(" (one of %s)", strings.Join(flag.Choices, ", "))
+			}
+			if flag.Default != "" {
+				usage += fmt.Sprintf(" (default %q)", flag.Default)
+			}
+			fmt.Fprintf(writer, "  %s\t%s\n", name, usage)
+		}
+		writer.Flush()
+	}
+
+	if len(command.examples) > 0 {
+		fmt.Println()
+		fmt.Println("Examples:")
+		for _, example := range command.examples {
+			fmt.Printf("  %s\n", example)
+		}
+	}
+
+	fmt.Println()
+}
+
+// findCommand returns the command with the given name or alias
+func findCommand(commands map[string]cliCommand, name string) (cliCommand, bool) {
+	if command, exists := commands[name]; exists {
+		return command, true
+	}
+	for _, command := range commands {
+		for _, alias := range command.aliases {
+			if alias == name {
+				return command, true
+			}
+		}
+	}
+	return cliCommand{}, false
+}
+
+// unknownCommandError suggests the command or alias closest to the given
+// name by edit distance
+func unknownCommandError(commands map[string]cliCommand, name string) error {
+	names := make([]string, 0, len(commands))
+	for _, command := range commands {
+		names = append(names, command.name)
+		names = append(names, command.aliases...)
+	}
+
+	if closest, found := fuzzy.Closest(name, names); found {
+		return fmt.Errorf("unknown command %q, did you mean %q?", name, closest)
+	}
+	return fmt.Errorf("unknown command %q, type help for a list of commands", name)
+}
+
+func sortKeys(mapToSort map[string]cliCommand) []string {
+	keys := make([]string, 0, len(mapToSort))
+
+	for key := range mapToSort {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
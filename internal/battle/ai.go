@@ -0,0 +1,149 @@
+package battle
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Strategy chooses the opponent's action for each turn of a battle
+type Strategy interface {
+	ChooseAction(b *Battle) Action
+}
+
+// StrategyNames returns the names of the available strategies
+func StrategyNames() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var strategies = map[string]func() Strategy{
+	"random":   func() Strategy { return RandomStrategy{} },
+	"greedy":   func() Strategy { return GreedyStrategy{} },
+	"switcher": func() Strategy { return &SwitcherStrategy{} },
+}
+
+// NewStrategy returns the strategy with the given name
+func NewStrategy(name string) (Strategy, error) {
+	newStrategy, found := strategies[name]
+	if !found {
+		return nil, fmt.Errorf("Unknown battle AI %q, expected one of %v", name, StrategyNames())
+	}
+	return newStrategy(), nil
+}
+
+// RandomStrategy uses a random move with PP left
+type RandomStrategy struct{}
+
+// ChooseAction implements Strategy
+func (RandomStrategy) ChooseAction(b *Battle) Action {
+	return UseMove(b.RandomMove(b.Opponent))
+}
+
+// GreedyStrategy uses the move expected to deal the most damage
+type GreedyStrategy struct{}
+
+// ChooseAction implements Strategy
+func (GreedyStrategy) ChooseAction(b *Battle) Action {
+	index, _ := bestMove(b.Opponent, b.Player)
+	return UseMove(index)
+}
+
+// SwitcherStrategy switches to a team member with a better type matchup
+// when the active opponent is at a disadvantage, and otherwise plays
+// like GreedyStrategy
+type SwitcherStrategy struct {
+	switched bool
+}
+
+// ChooseAction implements Strategy
+func (s *SwitcherStrategy) ChooseAction(b *Battle) Action {
+	// never switch twice in a row so the opponent can't stall forever
+	if !s.switched {
+		active := b.ActiveOpponent()
+		bestIndex, bestScore := active, matchup(b.Opponent, b.Player)
+		for i := range b.OpponentTeam {
+			candidate := &b.OpponentTeam[i]
+			if i == active || candidate.IsFainted() {
+				continue
+			}
+			if score := matchup(candidate, b.Player); score > bestScore*switchThreshold {
+				bestIndex, bestScore = i, score
+			}
+		}
+
+		if bestIndex != active {
+			s.switched = true
+			return SwitchTo(bestIndex)
+		}
+	}
+
+	s.switched = false
+	return GreedyStrategy{}.ChooseAction(b)
+}
+
+// switchThreshold is how much better a matchup must be to switch
+const switchThreshold = 1.5
+
+// matchup scores how well the combatant fares against the foe as the
+// ratio of the damage it is expected to deal to the damage it is
+// expected to receive
+func matchup(c, foe *Combatant) float64 {
+	_, dealt := bestMove(c, foe)
+	_, received := bestMove(foe, c)
+
+	return (dealt + 1) / (received + 1)
+}
+
+// bestMove returns the index of the move with PP left expected to deal the
+// most damage to the defender along with that damage, or -1 if the
+// attacker must struggle
+func bestMove(attacker, defender *Combatant) (int, float64) {
+	bestIndex, bestDamage := -1, -1.0
+	for i := range attacker.Moves {
+		move := &attacker.Moves[i]
+		if move.PP <= 0 {
+			continue
+		}
+		if damage := expectedDamage(attacker, defender, move); damage > bestDamage {
+			bestIndex, bestDamage = i, damage
+		}
+	}
+
+	if bestIndex == -1 {
+		return -1, expectedDamage(attacker, defender, &struggle)
+	}
+	return bestIndex, bestDamage
+}
+
+// expectedDamage estimates the damage of a move without any randomness,
+// weighting it by the move's accuracy
+func expectedDamage(attacker, defender *Combatant, move *Move) float64 {
+	if move.Class == Status || move.Power == 0 {
+		return 0
+	}
+
+	attack, defense := attacker.Stats.Attack, defender.Stats.Defense
+	if move.Class == Special {
+		attack, defense = attacker.Stats.SpecialAttack, defender.Stats.SpecialDefense
+	}
+	if defense < 1 {
+		defense = 1
+	}
+
+	damage := float64((2*attacker.Level/5+2)*move.Power*attack/defense)/50 + 2
+	if move.Type != "" {
+		damage *= TypeEffectiveness(move.Type, defender.Types)
+	}
+	if attacker.hasType(move.Type) {
+		damage *= 1.5
+	}
+	if move.Accuracy > 0 {
+		damage *= float64(move.Accuracy) / 100
+	}
+
+	return damage
+}
@@ -0,0 +1,62 @@
+package battle
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewStrategy(t *testing.T) {
+	for _, name := range StrategyNames() {
+		if _, err := NewStrategy(name); err != nil {
+			t.Errorf("expected strategy %s to exist: %v", name, err)
+		}
+	}
+
+	if _, err := NewStrategy("unknown"); err == nil {
+		t.Errorf("expected an error for an unknown strategy")
+	}
+}
+
+func TestGreedyStrategyPicksMostDamagingMove(t *testing.T) {
+	player, opponent := testCombatants()
+	// the player is the defender here, so ember against bulbasaur is best
+	b := New(opponent, []Combatant{player}, rand.New(rand.NewSource(1)))
+
+	action := GreedyStrategy{}.ChooseAction(b)
+	if action.Switch || action.Index != 0 {
+		t.Errorf("expected greedy strategy to use ember, got %+v", action)
+	}
+}
+
+func TestSwitcherStrategySwitchesToBetterMatchup(t *testing.T) {
+	player, opponent := testCombatants()
+	squirtle := Combatant{
+		Name:  "squirtle",
+		Level: 50,
+		Types: []string{"water"},
+		Stats: CalculateStats(baseStats(44, 48, 65, 50, 64, 43), 50),
+		Moves: []Move{
+			{Name: "water-gun", Type: "water", Class: Special, Power: 40, Accuracy: 100, PP: 25, MaxPP: 25},
+		},
+	}
+	squirtle.HP = squirtle.Stats.HP
+
+	b := New(player, []Combatant{opponent, squirtle}, rand.New(rand.NewSource(1)))
+	strategy := &SwitcherStrategy{}
+
+	action := strategy.ChooseAction(b)
+	if !action.Switch || action.Index != 1 {
+		t.Fatalf("expected switcher strategy to switch to squirtle, got %+v", action)
+	}
+	if _, err := b.PlayTurn(0, action); err != nil {
+		t.Fatal(err)
+	}
+	if b.Opponent.Name != "squirtle" {
+		t.Errorf("expected squirtle to be in battle, got %s", b.Opponent.Name)
+	}
+
+	action = strategy.ChooseAction(b)
+	if action.Switch {
+		t.Errorf("expected switcher strategy not to switch twice in a row")
+	}
+}
@@ -0,0 +1,309 @@
+package battle
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// criticalHitChance is the chance of a critical hit (1 in 24)
+const criticalHitChance = 24
+
+// Battle is a turn-based battle between the player's combatant and a team
+// of opponent combatants, one of which is active at a time
+type Battle struct {
+	Player       *Combatant
+	Opponent     *Combatant
+	OpponentTeam []Combatant
+	Turn         int
+	Log          []string
+	rng          *rand.Rand
+}
+
+// Action is what the opponent does in a turn: use a move or switch to
+// another member of its team
+type Action struct {
+	Switch bool
+	Index  int
+}
+
+// UseMove returns the action of using the move with the given index
+func UseMove(index int) Action {
+	return Action{Index: index}
+}
+
+// SwitchTo returns the action of switching to the team member with the
+// given index
+func SwitchTo(index int) Action {
+	return Action{Switch: true, Index: index}
+}
+
+// New creates a new battle against one or more opponents, the first of
+// which starts the battle.  The random number generator determines
+// accuracy, critical hits, damage rolls and speed ties, so using the
+// same seed reproduces the same battle.
+func New(player Combatant, opponents []Combatant, rng *rand.Rand) *Battle {
+	b := &Battle{
+		Player:       &player,
+		OpponentTeam: opponents,
+		rng:          rng,
+	}
+	if len(opponents) > 0 {
+		b.Opponent = &b.OpponentTeam[0]
+	}
+
+	return b
+}
+
+// IsOver reports whether the player's combatant or the whole opponent
+// team has fainted
+func (b *Battle) IsOver() bool {
+	return b.Player.IsFainted() || b.nextOpponent() == -1
+}
+
+// Winner returns the combatant that won the battle, or nil if the battle
+// is not over
+func (b *Battle) Winner() *Combatant {
+	switch {
+	case b.Player.IsFainted():
+		return b.Opponent
+	case b.nextOpponent() == -1:
+		return b.Player
+	default:
+		return nil
+	}
+}
+
+// ActiveOpponent returns the index of the active opponent in the team
+func (b *Battle) ActiveOpponent() int {
+	for i := range b.OpponentTeam {
+		if &b.OpponentTeam[i] == b.Opponent {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextOpponent returns the index of the first opponent that has not
+// fainted, or -1 if the whole team has fainted
+func (b *Battle) nextOpponent() int {
+	if b.Opponent != nil && !b.Opponent.IsFainted() {
+		return b.ActiveOpponent()
+	}
+	for i := range b.OpponentTeam {
+		if !b.OpponentTeam[i].IsFainted() {
+			return i
+		}
+	}
+	return -1
+}
+
+// RandomMove returns the index of a random move with PP left, or -1 if
+// the combatant must struggle
+func (b *Battle) RandomMove(c *Combatant) int {
+	usable := make([]int, 0, len(c.Moves))
+	for i, move := range c.Moves {
+		if move.PP > 0 {
+			usable = append(usable, i)
+		}
+	}
+	if len(usable) == 0 {
+		return -1
+	}
+
+	return usable[b.rng.Intn(len(usable))]
+}
+
+// PlayTurn plays a single turn with the player's move index and the
+// opponent's action and returns the log entries of the turn.  A move
+// index of -1 makes the combatant struggle.  Switching happens before
+// any move is used.
+func (b *Battle) PlayTurn(playerMove int, opponent Action) ([]string, error) {
+	if b.IsOver() {
+		return nil, errors.New("The battle is already over")
+	}
+
+	first, err := b.selectMove(b.Player, playerMove)
+	if err != nil {
+		return nil, err
+	}
+
+	var second *Move
+	if opponent.Switch {
+		if err := b.checkSwitch(opponent.Index); err != nil {
+			return nil, err
+		}
+	} else {
+		second, err = b.selectMove(b.Opponent, opponent.Index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b.Turn++
+	log := []string{fmt.Sprintf("Turn %d", b.Turn)}
+
+	if opponent.Switch {
+		b.Opponent = &b.OpponentTeam[opponent.Index]
+		log = append(log, fmt.Sprintf("The opponent sent out %s!", b.Opponent.Name))
+		log = append(log, b.attack(b.Player, b.Opponent, first)...)
+		log = append(log, b.replaceFaintedOpponent()...)
+
+		b.Log = append(b.Log, log...)
+		return log, nil
+	}
+
+	attacker, defender := b.Player, b.Opponent
+	if b.opponentMovesFirst(first, second) {
+		attacker, defender = defender, attacker
+		first, second = second, first
+	}
+
+	log = append(log, b.attack(attacker, defender, first)...)
+	if !defender.IsFainted() && !attacker.IsFainted() {
+		log = append(log, b.attack(defender, attacker, second)...)
+	}
+	log = append(log, b.replaceFaintedOpponent()...)
+
+	b.Log = append(b.Log, log...)
+	return log, nil
+}
+
+func (b *Battle) checkSwitch(index int) error {
+	if index < 0 || index >= len(b.OpponentTeam) {
+		return fmt.Errorf("The opponent does not have team member %d", index+1)
+	}
+	if &b.OpponentTeam[index] == b.Opponent {
+		return fmt.Errorf("%s is already in battle", b.Opponent.Name)
+	}
+	if b.OpponentTeam[index].IsFainted() {
+		return fmt.Errorf("%s has fainted", b.OpponentTeam[index].Name)
+	}
+	return nil
+}
+
+// replaceFaintedOpponent sends out the next opponent if the active one
+// has fainted and the player's combatant is still standing
+func (b *Battle) replaceFaintedOpponent() []string {
+	if !b.Opponent.IsFainted() || b.Player.IsFainted() {
+		return nil
+	}
+
+	next := b.nextOpponent()
+	if next == -1 {
+		return nil
+	}
+	b.Opponent = &b.OpponentTeam[next]
+	return []string{fmt.Sprintf("The opponent sent out %s!", b.Opponent.Name)}
+}
+
+func (b *Battle) selectMove(c *Combatant, index int) (*Move, error) {
+	if index == -1 {
+		if c.HasUsableMove() {
+			return nil, fmt.Errorf("%s still has moves with PP left", c.Name)
+		}
+		move := struggle
+		return &move, nil
+	}
+	if index < 0 || index >= len(c.Moves) {
+		return nil, fmt.Errorf("%s does not have move %d", c.Name, index+1)
+	}
+
+	move := &c.Moves[index]
+	if move.PP <= 0 {
+		return nil, fmt.Errorf("%s has no PP left for %s", c.Name, move.Name)
+	}
+
+	return move, nil
+}
+
+func (b *Battle) opponentMovesFirst(playerMove, opponentMove *Move) bool {
+	if playerMove.Priority != opponentMove.Priority {
+		return opponentMove.Priority > playerMove.Priority
+	}
+	if b.Player.Stats.Speed != b.Opponent.Stats.Speed {
+		return b.Opponent.Stats.Speed > b.Player.Stats.Speed
+	}
+	return b.rng.Intn(2) == 0
+}
+
+func (b *Battle) attack(attacker, defender *Combatant, move *Move) []string {
+	move.PP--
+	log := []string{fmt.Sprintf("%s used %s!", attacker.Name, move.Name)}
+
+	if move.Accuracy > 0 && b.rng.Intn(100) >= move.Accuracy {
+		return append(log, fmt.Sprintf("%s's attack missed!", attacker.Name))
+	}
+	if move.Class == Status || move.Power == 0 {
+		return append(log, "But nothing happened!")
+	}
+
+	effectiveness := 1.0
+	if move.Type != "" {
+		effectiveness = TypeEffectiveness(move.Type, defender.Types)
+	}
+	if effectiveness == 0 {
+		return append(log, fmt.Sprintf("It doesn't affect %s...", defender.Name))
+	}
+
+	critical := b.rng.Intn(criticalHitChance) == 0
+	damage := b.calculateDamage(attacker, defender, move, effectiveness, critical)
+	defender.HP -= damage
+	if defender.HP < 0 {
+		defender.HP = 0
+	}
+
+	if critical {
+		log = append(log, "A critical hit!")
+	}
+	if effectiveness > 1 {
+		log = append(log, "It's super effective!")
+	} else if effectiveness < 1 {
+		log = append(log, "It's not very effective...")
+	}
+	log = append(log, fmt.Sprintf("%s took %d damage (%d/%d HP)", defender.Name, damage, defender.HP, defender.Stats.HP))
+
+	if move.Name == struggle.Name {
+		recoil := max(attacker.Stats.HP/4, 1)
+		attacker.HP = max(attacker.HP-recoil, 0)
+		log = append(log, fmt.Sprintf("%s is hit with recoil!", attacker.Name))
+	}
+
+	if defender.IsFainted() {
+		log = append(log, fmt.Sprintf("%s fainted!", defender.Name))
+	}
+	if attacker.IsFainted() {
+		log = append(log, fmt.Sprintf("%s fainted!", attacker.Name))
+	}
+
+	return log
+}
+
+// calculateDamage applies the standard damage formula with STAB, type
+// effectiveness, critical hits and a random roll between 85% and 100%
+func (b *Battle) calculateDamage(attacker, defender *Combatant, move *Move, effectiveness float64, critical bool) int {
+	attack, defense := attacker.Stats.Attack, defender.Stats.Defense
+	if move.Class == Special {
+		attack, defense = attacker.Stats.SpecialAttack, defender.Stats.SpecialDefense
+	}
+	if defense < 1 {
+		defense = 1
+	}
+
+	base := float64((2*attacker.Level/5+2)*move.Power*attack/defense)/50 + 2
+
+	modifier := effectiveness
+	if attacker.hasType(move.Type) {
+		modifier *= 1.5
+	}
+	if critical {
+		modifier *= 1.5
+	}
+	modifier *= float64(85+b.rng.Intn(16)) / 100
+
+	damage := int(base * modifier)
+	if damage < 1 {
+		damage = 1
+	}
+	return damage
+}
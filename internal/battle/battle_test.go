@@ -0,0 +1,171 @@
+package battle
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+func baseStats(hp, attack, defense, specialAttack, specialDefense, speed int) []pokemon.Stats {
+	values := []struct {
+		name  string
+		value int
+	}{
+		{"hp", hp},
+		{"attack", attack},
+		{"defense", defense},
+		{"special-attack", specialAttack},
+		{"special-defense", specialDefense},
+		{"speed", speed},
+	}
+
+	stats := make([]pokemon.Stats, 0, len(values))
+	for _, v := range values {
+		stats = append(stats, pokemon.Stats{BaseStat: v.value, Stat: pokemon.Stat{Name: v.name}})
+	}
+	return stats
+}
+
+func testCombatants() (Combatant, Combatant) {
+	player := Combatant{
+		Name:  "charmander",
+		Level: 50,
+		Types: []string{"fire"},
+		Stats: CalculateStats(baseStats(39, 52, 43, 60, 50, 65), 50),
+		Moves: []Move{
+			{Name: "ember", Type: "fire", Class: Special, Power: 40, Accuracy: 100, PP: 25, MaxPP: 25},
+			{Name: "scratch", Type: "normal", Class: Physical, Power: 40, Accuracy: 100, PP: 35, MaxPP: 35},
+		},
+	}
+	player.HP = player.Stats.HP
+
+	opponent := Combatant{
+		Name:  "bulbasaur",
+		Level: 50,
+		Types: []string{"grass", "poison"},
+		Stats: CalculateStats(baseStats(45, 49, 49, 65, 65, 45), 50),
+		Moves: []Move{
+			{Name: "vine-whip", Type: "grass", Class: Physical, Power: 45, Accuracy: 100, PP: 25, MaxPP: 25},
+		},
+	}
+	opponent.HP = opponent.Stats.HP
+
+	return player, opponent
+}
+
+func TestCalculateStats(t *testing.T) {
+	cases := []struct {
+		level    int
+		expected Stats
+	}{
+		{
+			level:    50,
+			expected: Stats{HP: 99, Attack: 57, Defense: 48, SpecialAttack: 65, SpecialDefense: 55, Speed: 70},
+		},
+		{
+			level:    100,
+			expected: Stats{HP: 188, Attack: 109, Defense: 91, SpecialAttack: 125, SpecialDefense: 105, Speed: 135},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			stats := CalculateStats(baseStats(39, 52, 43, 60, 50, 65), c.level)
+			if stats != c.expected {
+				t.Errorf("expected %+v, got %+v", c.expected, stats)
+			}
+		})
+	}
+}
+
+func TestTypeEffectiveness(t *testing.T) {
+	cases := []struct {
+		attackType    string
+		defenderTypes []string
+		expected      float64
+	}{
+		{"fire", []string{"grass"}, 2},
+		{"fire", []string{"grass", "bug"}, 4},
+		{"water", []string{"grass", "dragon"}, 0.25},
+		{"normal", []string{"ghost"}, 0},
+		{"electric", []string{"normal"}, 1},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := TypeEffectiveness(c.attackType, c.defenderTypes)
+			if actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBattleIsReproducible(t *testing.T) {
+	const seed = 42
+
+	play := func() []string {
+		player, opponent := testCombatants()
+		b := New(player, []Combatant{opponent}, rand.New(rand.NewSource(seed)))
+		for !b.IsOver() {
+			if _, err := b.PlayTurn(0, UseMove(b.RandomMove(b.Opponent))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return b.Log
+	}
+
+	first := play()
+	second := play()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected battles with the same seed to be identical")
+	}
+}
+
+func TestSuperEffectiveMoveWins(t *testing.T) {
+	player, opponent := testCombatants()
+	b := New(player, []Combatant{opponent}, rand.New(rand.NewSource(1)))
+	for !b.IsOver() {
+		if _, err := b.PlayTurn(0, UseMove(0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if b.Winner() != b.Player {
+		t.Errorf("expected %s to win", b.Player.Name)
+	}
+}
+
+func TestPlayTurnRejectsInvalidMoves(t *testing.T) {
+	player, opponent := testCombatants()
+	player.Moves[1].PP = 0
+	b := New(player, []Combatant{opponent}, rand.New(rand.NewSource(1)))
+
+	if _, err := b.PlayTurn(1, UseMove(0)); err == nil {
+		t.Errorf("expected an error for a move without PP")
+	}
+	if _, err := b.PlayTurn(5, UseMove(0)); err == nil {
+		t.Errorf("expected an error for a move that does not exist")
+	}
+	if _, err := b.PlayTurn(-1, UseMove(0)); err == nil {
+		t.Errorf("expected an error when struggling with moves left")
+	}
+}
+
+func TestCalculateIndividualStats(t *testing.T) {
+	ivs := Stats{HP: 31, Attack: 31, Defense: 31, SpecialAttack: 31, SpecialDefense: 31, Speed: 31}
+	evs := Stats{Speed: 252}
+	nature := pokemon.Nature{
+		IncreasedStat: &pokemon.StatNR{NamedAPIResource: pokemon.NamedAPIResource{Name: "speed"}},
+		DecreasedStat: &pokemon.StatNR{NamedAPIResource: pokemon.NamedAPIResource{Name: "attack"}},
+	}
+
+	stats := CalculateIndividualStats(baseStats(39, 52, 43, 60, 50, 65), 100, ivs, evs, nature)
+	expected := Stats{HP: 219, Attack: 126, Defense: 122, SpecialAttack: 156, SpecialDefense: 136, Speed: 251}
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
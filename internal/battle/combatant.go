@@ -0,0 +1,195 @@
+package battle
+
+import (
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// DamageClass is the kind of damage a move inflicts
+type DamageClass string
+
+const (
+	// Physical moves use Attack and Defense
+	Physical DamageClass = "physical"
+
+	// Special moves use Special Attack and Special Defense
+	Special DamageClass = "special"
+
+	// Status moves do not inflict damage
+	Status DamageClass = "status"
+)
+
+// Stats contains the computed battle statistics of a combatant
+type Stats struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"special_attack"`
+	SpecialDefense int `json:"special_defense"`
+	Speed          int `json:"speed"`
+}
+
+// Move is a move as used in battle
+type Move struct {
+	Name     string
+	Type     string
+	Class    DamageClass
+	Power    int
+	Accuracy int // 0 means the move never misses
+	PP       int
+	MaxPP    int
+	Priority int
+}
+
+// struggle is used when a combatant has no PP left in any of its moves
+var struggle = Move{
+	Name:     "struggle",
+	Class:    Physical,
+	Power:    50,
+	PP:       1,
+	MaxPP:    1,
+	Priority: 0,
+}
+
+// Combatant is a Pokemon taking part in a battle
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	Stats Stats
+	HP    int
+	Moves []Move
+}
+
+// NewMove converts a move returned by the Pokemon API into a battle move
+func NewMove(move pokemon.Move) Move {
+	battleMove := Move{
+		Name:     move.Name,
+		Type:     move.Type.Name,
+		Class:    DamageClass(move.DamageClass.Name),
+		PP:       move.PP,
+		MaxPP:    move.PP,
+		Priority: move.Priority,
+	}
+	if move.Power != nil {
+		battleMove.Power = *move.Power
+	}
+	if move.Accuracy != nil {
+		battleMove.Accuracy = *move.Accuracy
+	}
+
+	return battleMove
+}
+
+// NewCombatant creates a combatant for the given Pokemon at the given level
+// with the given computed stats
+func NewCombatant(p pokemon.Pokemon, level int, stats Stats, moves []Move) Combatant {
+	types := make([]string, 0, len(p.Types))
+	for _, pokemonType := range p.Types {
+		types = append(types, pokemonType.Type.Name)
+	}
+
+	return Combatant{
+		Name:  p.Name,
+		Level: level,
+		Types: types,
+		Stats: stats,
+		HP:    stats.HP,
+		Moves: moves,
+	}
+}
+
+// CalculateStats computes the battle statistics from the base stats at
+// the given level, without individual values, effort values or nature
+func CalculateStats(baseStats []pokemon.Stats, level int) Stats {
+	return CalculateIndividualStats(baseStats, level, Stats{}, Stats{}, pokemon.Nature{})
+}
+
+// CalculateIndividualStats computes the battle statistics from the base
+// stats at the given level for an individual with the given individual
+// values, effort values and nature
+func CalculateIndividualStats(baseStats []pokemon.Stats, level int, ivs, evs Stats, nature pokemon.Nature) Stats {
+	stats := Stats{}
+	for _, stat := range baseStats {
+		name := stat.Stat.Name
+		value := ((2*stat.BaseStat + ivs.Get(name) + evs.Get(name)/4) * level) / 100
+		if name == "hp" {
+			stats.Set(name, value+level+10)
+			continue
+		}
+
+		value += 5
+		if nature.IncreasedStat != nil && nature.IncreasedStat.Name == name {
+			value = value * 110 / 100
+		}
+		if nature.DecreasedStat != nil && nature.DecreasedStat.Name == name {
+			value = value * 90 / 100
+		}
+		stats.Set(name, value)
+	}
+
+	return stats
+}
+
+// Get returns the value of the stat with the given API name
+func (s Stats) Get(name string) int {
+	switch name {
+	case "hp":
+		return s.HP
+	case "attack":
+		return s.Attack
+	case "defense":
+		return s.Defense
+	case "special-attack":
+		return s.SpecialAttack
+	case "special-defense":
+		return s.SpecialDefense
+	case "speed":
+		return s.Speed
+	}
+	return 0
+}
+
+// Set sets the value of the stat with the given API name
+func (s *Stats) Set(name string, value int) {
+	switch name {
+	case "hp":
+		s.HP = value
+	case "attack":
+		s.Attack = value
+	case "defense":
+		s.Defense = value
+	case "special-attack":
+		s.SpecialAttack = value
+	case "special-defense":
+		s.SpecialDefense = value
+	case "speed":
+		s.Speed = value
+	}
+}
+
+// StatNames contains the API names of the stats in display order
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// IsFainted reports whether the combatant has no HP left
+func (c *Combatant) IsFainted() bool {
+	return c.HP <= 0
+}
+
+// HasUsableMove reports whether the combatant has PP left in any move
+func (c *Combatant) HasUsableMove() bool {
+	for _, move := range c.Moves {
+		if move.PP > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Combatant) hasType(typeName string) bool {
+	for _, t := range c.Types {
+		if t == typeName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "explore  pastoria-city-area", expected: []string{"explore", "pastoria-city-area"}},
+		{input: "  catch pikachu  ", expected: []string{"catch", "pikachu"}},
+		{input: `nickname 1 "Mr Sparky"`, expected: []string{"nickname", "1", "Mr Sparky"}},
+		{input: `nickname 1 'it\'s'`, expected: nil},
+		{input: `nickname 1 Mr\ Sparky`, expected: []string{"nickname", "1", "Mr Sparky"}},
+		{input: `say "a \"quoted\" word"`, expected: []string{"say", `a "quoted" word`}},
+		{input: `empty ""`, expected: []string{"empty", ""}},
+		{input: "", expected: []string{}},
+		{input: "explore $1; catch $2", expected: []string{"explore", "$1", Separator, "catch", "$2"}},
+		{input: `say "a;b" c\;d`, expected: []string{"say", "a;b", "c;d"}},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, err := Tokenize(c.input)
+			if c.expected == nil {
+				if err == nil {
+					t.Errorf("expected an error, got %q", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseSeparators(t *testing.T) {
+	commands := Spec{Args: []Arg{{Name: "body", Variadic: true, Separators: true}}}
+	args, err := commands.Parse([]string{"explore", Separator, "catch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"explore", Separator, "catch"}; !reflect.DeepEqual(args.List("body"), expected) {
+		t.Errorf("expected %q, got %q", expected, args.List("body"))
+	}
+
+	single := Spec{Args: []Arg{{Name: "pokemon", Variadic: true}}}
+	if _, err := single.Parse([]string{"pikachu", Separator, "eevee"}); err == nil {
+		t.Errorf("expected an error for a separator in an argument that does not allow them")
+	}
+	if _, err := single.Parse([]string{"pikachu", ";"}); err != nil {
+		t.Errorf("expected a quoted semicolon to be allowed, got %v", err)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	cases := []string{"pikachu", "Mr Sparky", "it's", `a "quoted" word`, "a;b", `back\slash`, ";", ""}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, err := Tokenize(Quote(c))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, []string{c}) {
+				t.Errorf("expected %q to read back unchanged, got %q from %s", c, actual, Quote(c))
+			}
+		})
+	}
+	if actual := Quote("pikachu"); actual != "pikachu" {
+		t.Errorf("expected pikachu to be left unquoted, got %s", actual)
+	}
+}
+
+var battleSpec = Spec{
+	Flags: []Flag{
+		{Name: "ai", Default: "random", Choices: []string{"random", "greedy"}},
+		{Name: "shiny", Short: "s", Kind: Bool},
+	},
+	Args: []Arg{
+		{Name: "mine", Required: true},
+		{Name: "level", Kind: Int},
+		{Name: "rest", Variadic: true},
+	},
+}
+
+func TestParse(t *testing.T) {
+	args, err := battleSpec.Parse([]string{"Pikachu", "--ai=greedy", "-s", "12", "a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if args.String("mine") != "pikachu" {
+		t.Errorf("expected mine to be pikachu, got %q", args.String("mine"))
+	}
+	if args.String("ai") != "greedy" || !args.IsSet("ai") {
+		t.Errorf("expected ai to be set to greedy, got %q", args.String("ai"))
+	}
+	if !args.Bool("shiny") {
+		t.Errorf("expected shiny to be true")
+	}
+	if args.Int("level") != 12 {
+		t.Errorf("expected level 12, got %d", args.Int("level"))
+	}
+	if !reflect.DeepEqual(args.List("rest"), []string{"a", "b"}) {
+		t.Errorf("expected rest to be [a b], got %q", args.List("rest"))
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	args, err := battleSpec.Parse([]string{"pikachu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if args.String("ai") != "random" || args.IsSet("ai") {
+		t.Errorf("expected ai to default to random")
+	}
+	if args.IsSet("level") {
+		t.Errorf("expected level not to be set")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := [][]string{
+		{},
+		{"pikachu", "--ai", "smart"},
+		{"pikachu", "--ai"},
+		{"pikachu", "--unknown"},
+		{"pikachu", "high"},
+		{"pikachu", "--shiny=maybe"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if _, err := battleSpec.Parse(c); err == nil {
+				t.Errorf("expected an error for %q", c)
+			}
+		})
+	}
+
+	if _, err := (Spec{Args: []Arg{{Name: "name"}}}).Parse([]string{"a", "b"}); err == nil {
+		t.Errorf("expected an error for an unexpected argument")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	expected := "battle [--ai <random|greedy>] [--shiny] <mine> [level] [rest...]"
+	if usage := battleSpec.Usage("battle"); usage != expected {
+		t.Errorf("expected %q, got %q", expected, usage)
+	}
+}
+
+func TestArgIndex(t *testing.T) {
+	cases := []struct {
+		tokens   []string
+		expected int
+	}{
+		{tokens: []string{}, expected: 0},
+		{tokens: []string{"pikachu"}, expected: 1},
+		{tokens: []string{"--ai", "greedy"}, expected: 0},
+		{tokens: []string{"--ai=greedy", "pikachu", "-s"}, expected: 1},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if actual := battleSpec.ArgIndex(c.tokens); actual != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseFlagsFirst(t *testing.T) {
+	spec := Spec{
+		Flags:      []Flag{{Name: "script"}},
+		Args:       []Arg{{Name: "command", Variadic: true, CaseSensitive: true}},
+		FlagsFirst: true,
+	}
+
+	args, err := spec.Parse([]string{"--script", "a.txt", "battle", "--ai", "greedy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args.String("script") != "a.txt" {
+		t.Errorf("expected script a.txt, got %q", args.String("script"))
+	}
+	expected := []string{"battle", "--ai", "greedy"}
+	if !reflect.DeepEqual(args.List("command"), expected) {
+		t.Errorf("expected %q, got %q", expected, args.List("command"))
+	}
+
+	noFlags := Spec{Args: []Arg{{Name: "args", Variadic: true}}, FlagsFirst: true}
+	args, err = noFlags.Parse([]string{"--ai", "greedy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"--ai", "greedy"}; !reflect.DeepEqual(args.List("args"), expected) {
+		t.Errorf("expected %q, got %q", expected, args.List("args"))
+	}
+}
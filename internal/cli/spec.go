@@ -0,0 +1,305 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the type of value a flag or argument holds
+type Kind int
+
+const (
+	// String values are passed through as is
+	String Kind = iota
+
+	// Int values must be whole numbers
+	Int
+
+	// Bool flags take no value and are true when present
+	Bool
+)
+
+// Flag describes a named option such as --ai greedy
+type Flag struct {
+	Name          string
+	Short         string
+	Kind          Kind
+	Default       string
+	Usage         string
+	Choices       []string
+	CaseSensitive bool
+}
+
+// Arg describes a positional argument
+type Arg struct {
+	Name          string
+	Kind          Kind
+	Required      bool
+	Variadic      bool
+	Usage         string
+	CaseSensitive bool
+
+	// Separators allows the argument to hold Separator tokens, for
+	// arguments that are several commands
+	Separators bool
+}
+
+// Spec describes the flags and positional arguments a command accepts.
+// With FlagsFirst set, flags are only recognized before the first
+// positional argument so the remaining tokens can be passed on as is, and
+// a spec without flags passes on every token.
+type Spec struct {
+	Flags      []Flag
+	Args       []Arg
+	FlagsFirst bool
+}
+
+// Args contains the parsed flags and positional arguments of a command
+type Args struct {
+	values map[string][]string
+	set    map[string]bool
+}
+
+// Parse validates the tokens following a command name against the spec
+// and returns the parsed flags and arguments.  Unless a flag or argument
+// is case sensitive its value is lower cased.
+func (s Spec) Parse(tokens []string) (Args, error) {
+	args := Args{
+		values: make(map[string][]string),
+		set:    make(map[string]bool),
+	}
+	for _, flag := range s.Flags {
+		if flag.Default != "" {
+			args.values[flag.Name] = []string{flag.Default}
+		}
+	}
+
+	positional := []string{}
+	flagsDone := s.FlagsFirst && len(s.Flags) == 0
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if flagsDone || !isFlag(token) {
+			positional = append(positional, token)
+			flagsDone = flagsDone || s.FlagsFirst
+			continue
+		}
+		if token == "--" {
+			flagsDone = true
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(token, "-"), "=")
+		flag, found := s.findFlag(name)
+		if !found {
+			return Args{}, fmt.Errorf("unknown flag %s", token)
+		}
+
+		if flag.Kind == Bool {
+			if hasValue {
+				if _, err := strconv.ParseBool(value); err != nil {
+					return Args{}, fmt.Errorf("flag --%s expects true or false", flag.Name)
+				}
+			} else {
+				value = "true"
+			}
+		} else if !hasValue {
+			if i+1 >= len(tokens) {
+				return Args{}, fmt.Errorf("flag --%s needs a value", flag.Name)
+			}
+			i++
+			value = tokens[i]
+		}
+
+		value, err := flag.validate(value)
+		if err != nil {
+			return Args{}, err
+		}
+		args.values[flag.Name] = []string{value}
+		args.set[flag.Name] = true
+	}
+
+	if err := s.parsePositional(positional, &args); err != nil {
+		return Args{}, err
+	}
+
+	return args, nil
+}
+
+func (s Spec) parsePositional(positional []string, args *Args) error {
+	index := 0
+	for _, arg := range s.Args {
+		if index >= len(positional) {
+			if arg.Required {
+				return fmt.Errorf("missing required argument <%s>", arg.Name)
+			}
+			break
+		}
+
+		count := 1
+		if arg.Variadic {
+			count = len(positional) - index
+		}
+		for _, value := range positional[index : index+count] {
+			value, err := arg.validate(value)
+			if err != nil {
+				return err
+			}
+			args.values[arg.Name] = append(args.values[arg.Name], value)
+		}
+		args.set[arg.Name] = true
+		index += count
+	}
+
+	if index < len(positional) {
+		if positional[index] == Separator {
+			return errUnexpectedSeparator
+		}
+		return fmt.Errorf("unexpected argument %q", positional[index])
+	}
+	return nil
+}
+
+func (s Spec) findFlag(name string) (Flag, bool) {
+	for _, flag := range s.Flags {
+		if flag.Name == name || (flag.Short != "" && flag.Short == name) {
+			return flag, true
+		}
+	}
+	return Flag{}, false
+}
+
+// Usage returns a one line synopsis of the command, such as
+// "battle [--ai <random|greedy>] <mine> [opponent]"
+func (s Spec) Usage(name string) string {
+	parts := []string{name}
+	for _, flag := range s.Flags {
+		switch {
+		case flag.Kind == Bool:
+			parts = append(parts, fmt.Sprintf("[--%s]", flag.Name))
+		case len(flag.Choices) > 0:
+			parts = append(parts, fmt.Sprintf("[--%s <%s>]", flag.Name, strings.Join(flag.Choices, "|")))
+		default:
+			parts = append(parts, fmt.Sprintf("[--%s <%s>]", flag.Name, flag.Name))
+		}
+	}
+	for _, arg := range s.Args {
+		argName := arg.Name
+		if arg.Variadic {
+			argName += "..."
+		}
+		if arg.Required {
+			parts = append(parts, "<"+argName+">")
+		} else {
+			parts = append(parts, "["+argName+"]")
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (f Flag) validate(value string) (string, error) {
+	if !f.CaseSensitive {
+		value = strings.ToLower(value)
+	}
+	if f.Kind == Int {
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("flag --%s expects a number, got %q", f.Name, value)
+		}
+	}
+	if len(f.Choices) > 0 {
+		for _, choice := range f.Choices {
+			if value == choice {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("flag --%s expects one of %s, got %q", f.Name, strings.Join(f.Choices, ", "), value)
+	}
+	return value, nil
+}
+
+func (a Arg) validate(value string) (string, error) {
+	if value == Separator && !a.Separators {
+		return "", errUnexpectedSeparator
+	}
+	if !a.CaseSensitive {
+		value = strings.ToLower(value)
+	}
+	if a.Kind == Int {
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("argument <%s> expects a number, got %q", a.Name, value)
+		}
+	}
+	return value, nil
+}
+
+var errUnexpectedSeparator = errors.New("unexpected ;, quote it to pass a semicolon")
+
+// isFlag reports whether a token is a flag rather than a positional
+// argument; negative numbers are treated as positional arguments
+func isFlag(token string) bool {
+	if len(token) < 2 || token[0] != '-' {
+		return false
+	}
+	_, err := strconv.Atoi(token)
+	return err != nil
+}
+
+// String returns the value of a flag or positional argument, or "" if it
+// was not given and has no default
+func (a Args) String(name string) string {
+	if values := a.values[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Int returns the value of an Int flag or positional argument, or 0 if it
+// was not given and has no default
+func (a Args) Int(name string) int {
+	value, _ := strconv.Atoi(a.String(name))
+	return value
+}
+
+// Bool returns whether a Bool flag was given
+func (a Args) Bool(name string) bool {
+	value, _ := strconv.ParseBool(a.String(name))
+	return value
+}
+
+// List returns all values of a variadic positional argument
+func (a Args) List(name string) []string {
+	return a.values[name]
+}
+
+// IsSet reports whether a flag or positional argument was given on the
+// command line, as opposed to taking its default
+func (a Args) IsSet(name string) bool {
+	return a.set[name]
+}
+
+// ArgIndex returns the index of the positional argument that the token
+// following the given tokens would fill, skipping flags and their values
+func (s Spec) ArgIndex(tokens []string) int {
+	index := 0
+	flagsDone := s.FlagsFirst && len(s.Flags) == 0
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if flagsDone || !isFlag(token) {
+			index++
+			continue
+		}
+		if token == "--" {
+			flagsDone = true
+			continue
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimLeft(token, "-"), "=")
+		if flag, found := s.findFlag(name); found && flag.Kind != Bool && !hasValue {
+			i++
+		}
+	}
+
+	return index
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// Separator is the token Tokenize returns for a semicolon outside quotes,
+// which separates commands.  It is not a semicolon itself so it can be
+// told apart from a quoted one.
+const Separator = "\x00;"
+
+// Tokenize splits a command line into arguments the way a shell does:
+// runs of whitespace separate arguments, single quotes preserve everything
+// literally, double quotes preserve whitespace while allowing backslash
+// escapes, and a backslash outside quotes escapes the next character.  A
+// semicolon outside quotes is returned as Separator.
+func Tokenize(line string) ([]string, error) {
+	tokens := []string{}
+	var current strings.Builder
+	inToken := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inToken = true
+		case r == '\'' || r == '"':
+			quote = r
+			inToken = true
+		case r == ';':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+			tokens = append(tokens, Separator)
+		case unicode.IsSpace(r):
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if escaped {
+		return nil, errors.New("unfinished escape at end of line")
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+// Quote returns the argument quoted so Tokenize reads it back unchanged,
+// leaving it as it is when nothing in it needs quoting
+func Quote(arg string) string {
+	if arg != "" && !strings.ContainsFunc(arg, needsQuote) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+func needsQuote(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`'"\;`, r)
+}
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts the name of every environment variable that overrides a
+// setting
+const EnvPrefix = "POKEDEX_"
+
+// Source is where the value of a setting came from.  Flags take precedence
+// over environment variables, which take precedence over the config file,
+// which takes precedence over the defaults.
+type Source string
+
+const (
+	// Default is the value a setting has when it is not set anywhere
+	Default Source = "default"
+
+	// File is a value saved in the config file
+	File Source = "file"
+
+	// Env is a value from a POKEDEX_* environment variable
+	Env Source = "env"
+
+	// Flag is a value from a command line flag
+	Flag Source = "flag"
+)
+
+// Setting describes a setting, its default and how its values are checked
+type Setting struct {
+	Name    string
+	Default string
+	Usage   string
+
+	// Validate returns an error if the value is not valid for the setting
+	Validate func(value string) error
+}
+
+// Config holds the values of the settings from each source
+type Config struct {
+	path     string
+	settings map[string]Setting
+	file     map[string]string
+	env      map[string]string
+	flags    map[string]string
+
+	// kept are the entries of the file that are not used because their
+	// setting is unknown or their value is not valid.  They are written
+	// back as they were so saving does not lose them.
+	kept map[string]json.RawMessage
+
+	// loadErr is why the file could not be read, in which case it is not
+	// saved over so the settings in it are not lost
+	loadErr error
+}
+
+// New creates a config for the settings whose file is kept at the path
+func New(path string, settings []Setting) *Config {
+	c := &Config{
+		path:     path,
+		settings: make(map[string]Setting),
+		file:     make(map[string]string),
+		env:      make(map[string]string),
+		flags:    make(map[string]string),
+		kept:     make(map[string]json.RawMessage),
+	}
+	for _, setting := range settings {
+		c.settings[setting.Name] = setting
+	}
+	return c
+}
+
+// EnvName returns the name of the environment variable for a setting
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Load reads the config file, which may be missing, and the environment.
+// Values that are not valid for their setting are reported and ignored,
+// but kept in the file when it is saved.
+// If the file cannot be read at all it is reported and never saved over.
+func (c *Config) Load() error {
+	var errs []error
+
+	data, err := os.ReadFile(c.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		c.loadErr = err
+		errs = append(errs, err)
+	default:
+		values := map[string]json.RawMessage{}
+		if err := json.Unmarshal(data, &values); err != nil {
+			c.loadErr = fmt.Errorf("reading %s: %w", c.path, err)
+			errs = append(errs, c.loadErr)
+		}
+		for name, raw := range values {
+			value, err := scalarString(raw)
+			if err != nil {
+				err = fmt.Errorf("invalid %s: %w", name, err)
+			} else {
+				err = c.validate(name, value)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", c.path, err))
+				c.kept[name] = raw
+				continue
+			}
+			c.file[name] = value
+		}
+	}
+
+	for name := range c.settings {
+		value, found := os.LookupEnv(EnvName(name))
+		if !found {
+			continue
+		}
+		if err := c.validate(name, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", EnvName(name), err))
+			continue
+		}
+		c.env[name] = value
+	}
+
+	return errors.Join(errs...)
+}
+
+// SetFlag sets a setting from a command line flag for this run only
+func (c *Config) SetFlag(name string, value string) error {
+	if err := c.validate(name, value); err != nil {
+		return err
+	}
+	c.flags[name] = value
+	return nil
+}
+
+// Get returns the value of a setting and where it came from
+func (c *Config) Get(name string) (string, Source, error) {
+	setting, exists := c.settings[name]
+	if !exists {
+		return "", "", c.unknownSettingError(name)
+	}
+
+	if value, found := c.flags[name]; found {
+		return value, Flag, nil
+	}
+	if value, found := c.env[name]; found {
+		return value, Env, nil
+	}
+	if value, found := c.file[name]; found {
+		return value, File, nil
+	}
+	return setting.Default, Default, nil
+}
+
+// Value returns the value of a setting, or an empty string if there is no
+// such setting
+func (c *Config) Value(name string) string {
+	value, _, _ := c.Get(name)
+	return value
+}
+
+// Set saves the value of a setting in the config file
+func (c *Config) Set(name string, value string) error {
+	if err := c.validate(name, value); err != nil {
+		return err
+	}
+	c.file[name] = value
+	delete(c.kept, name)
+	return c.save()
+}
+
+// Unset removes a setting from the config file so it takes its default
+func (c *Config) Unset(name string) error {
+	if _, exists := c.settings[name]; !exists {
+		return c.unknownSettingError(name)
+	}
+	delete(c.file, name)
+	delete(c.kept, name)
+	return c.save()
+}
+
+// Settings returns the settings sorted by name
+func (c *Config) Settings() []Setting {
+	settings := make([]Setting, 0, len(c.settings))
+	for _, setting := range c.settings {
+		settings = append(settings, setting)
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Name < settings[j].Name
+	})
+	return settings
+}
+
+// Path returns the path of the config file
+func (c *Config) Path() string {
+	return c.path
+}
+
+func (c *Config) validate(name string, value string) error {
+	setting, exists := c.settings[name]
+	if !exists {
+		return c.unknownSettingError(name)
+	}
+	if setting.Validate == nil {
+		return nil
+	}
+	if err := setting.Validate(value); err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return nil
+}
+
+// scalarString returns a string, number or boolean from the config file as
+// the string the settings are validated and stored as
+func scalarString(raw json.RawMessage) (string, error) {
+	var decoded any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return "", err
+	}
+
+	switch value := decoded.(type) {
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	default:
+		return "", fmt.Errorf("expected a string, number or boolean, got %s", raw)
+	}
+}
+
+func (c *Config) save() error {
+	if c.loadErr != nil {
+		return fmt.Errorf("not saving over %s, fix it first: %w", c.path, c.loadErr)
+	}
+	values := make(map[string]any, len(c.kept)+len(c.file))
+	for name, raw := range c.kept {
+		values[name] = raw
+	}
+	for name, value := range c.file {
+		values[name] = value
+	}
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
+
+func (c *Config) unknownSettingError(name string) error {
+	names := make([]string, 0, len(c.settings))
+	for _, setting := range c.Settings() {
+		names = append(names, setting.Name)
+	}
+	return fmt.Errorf("unknown setting %q, expected one of %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var testSettings = []Setting{
+	{Name: "page-size", Default: "20", Validate: func(value string) error {
+		_, err := strconv.Atoi(value)
+		return err
+	}},
+	{Name: "base-url", Default: "https://pokeapi.co/api/v2/"},
+}
+
+func TestPrecedence(t *testing.T) {
+	cases := []struct {
+		file           string
+		env            string
+		flag           string
+		expectedValue  string
+		expectedSource Source
+	}{
+		{expectedValue: "20", expectedSource: Default},
+		{file: "30", expectedValue: "30", expectedSource: File},
+		{file: "30", env: "40", expectedValue: "40", expectedSource: Env},
+		{file: "30", env: "40", flag: "50", expectedValue: "50", expectedSource: Flag},
+		{file: "30", env: "not a number", expectedValue: "30", expectedSource: File},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if c.file != "" {
+				if err := os.WriteFile(path, []byte(`{"page-size": "`+c.file+`"}`), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if c.env != "" {
+				t.Setenv("POKEDEX_PAGE_SIZE", c.env)
+			}
+
+			config := New(path, testSettings)
+			err := config.Load()
+			if c.env == "not a number" && err == nil {
+				t.Errorf("expected an error for the invalid environment variable")
+			}
+			if c.flag != "" {
+				if err := config.SetFlag("page-size", c.flag); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			value, source, err := config.Get("page-size")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value != c.expectedValue || source != c.expectedSource {
+				t.Errorf("expected %s from %s, actual %s from %s", c.expectedValue, c.expectedSource, value, source)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedexcli", "config.json")
+	config := New(path, testSettings)
+
+	if err := config.Set("page-size", "many"); err == nil {
+		t.Errorf("expected an error setting an invalid value")
+	}
+	if err := config.Set("colour", "red"); err == nil {
+		t.Errorf("expected an error setting an unknown setting")
+	}
+	if err := config.Set("page-size", "50"); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := New(path, testSettings)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if value := reloaded.Value("page-size"); value != "50" {
+		t.Errorf("expected the saved value 50, actual %s", value)
+	}
+
+	if err := reloaded.Unset("page-size"); err != nil {
+		t.Fatal(err)
+	}
+	if value, source, _ := reloaded.Get("page-size"); value != "20" || source != Default {
+		t.Errorf("expected the default after unset, actual %s from %s", value, source)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	config := New(filepath.Join(t.TempDir(), "missing.json"), testSettings)
+	if err := config.Load(); err != nil {
+		t.Errorf("expected a missing file to be ignored, got %v", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	cases := []struct {
+		contents       string
+		expectedValue  string
+		expectedSource Source
+		expectLoadErr  bool
+		expectSaveErr  bool
+	}{
+		{contents: `{"page-size": "30"}`, expectedValue: "30", expectedSource: File},
+		{contents: `{"page-size": 30}`, expectedValue: "30", expectedSource: File},
+		{contents: `{"page-size": [30]}`, expectedValue: "20", expectedSource: Default, expectLoadErr: true},
+		{contents: `{"page-size": 30`, expectedValue: "20", expectedSource: Default, expectLoadErr: true, expectSaveErr: true},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(c.contents), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			config := New(path, testSettings)
+			if err := config.Load(); (err != nil) != c.expectLoadErr {
+				t.Errorf("expected a load error %v, actual %v", c.expectLoadErr, err)
+			}
+			value, source, err := config.Get("page-size")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value != c.expectedValue || source != c.expectedSource {
+				t.Errorf("expected %s from %s, actual %s from %s", c.expectedValue, c.expectedSource, value, source)
+			}
+
+			err = config.Set("base-url", "http://localhost/")
+			if (err != nil) != c.expectSaveErr {
+				t.Errorf("expected a save error %v, actual %v", c.expectSaveErr, err)
+			}
+			if c.expectSaveErr {
+				if data, _ := os.ReadFile(path); string(data) != c.contents {
+					t.Errorf("expected the unreadable file to be kept, actual %s", data)
+				}
+			}
+		})
+	}
+}
+
+func TestSaveKeepsUnusedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"page-size": "many", "colour": ["red"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := New(path, testSettings)
+	if err := config.Load(); err == nil {
+		t.Errorf("expected errors for the unknown setting and the invalid value")
+	}
+	if err := config.Set("base-url", "http://localhost/"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]any{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{"page-size": "many", "colour": []any{"red"}, "base-url": "http://localhost/"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %v to be saved, actual %v", expected, values)
+	}
+}
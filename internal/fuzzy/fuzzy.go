@@ -0,0 +1,93 @@
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+)
+
+// Distance returns the edit distance between two strings: the number of
+// single character insertions, deletions, substitutions and transpositions
+// of adjacent characters needed to turn one into the other
+func Distance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	d := make([][]int, len(source)+1)
+	for i := range d {
+		d[i] = make([]int, len(target)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && source[i-1] == target[j-2] && source[i-2] == target[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(source)][len(target)]
+}
+
+// Closest returns the candidate with the smallest edit distance to name,
+// provided it is close enough to be a plausible typo
+func Closest(name string, candidates []string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := Distance(name, candidate)
+		if bestDistance == -1 || distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	if bestDistance == -1 || bestDistance > maxTypos(name) {
+		return "", false
+	}
+	return best, true
+}
+
+// Suggest returns up to limit candidates that name could have been meant
+// as: those starting with name, then those close enough to be a plausible
+// typo, closest first
+func Suggest(name string, candidates []string, limit int) []string {
+	type suggestion struct {
+		candidate string
+		distance  int
+	}
+	suggestions := []suggestion{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, name) {
+			suggestions = append(suggestions, suggestion{candidate, 0})
+		} else if distance := Distance(name, candidate); distance <= maxTypos(name) {
+			suggestions = append(suggestions, suggestion{candidate, distance})
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		if len(a.candidate) != len(b.candidate) {
+			return len(a.candidate) < len(b.candidate)
+		}
+		return a.candidate < b.candidate
+	})
+
+	names := make([]string, 0, min(limit, len(suggestions)))
+	for _, s := range suggestions[:min(limit, len(suggestions))] {
+		names = append(names, s.candidate)
+	}
+	return names
+}
+
+// maxTypos returns how many edits a name may be off by and still match
+func maxTypos(name string) int {
+	return max(len([]rune(name))/3, 1)
+}
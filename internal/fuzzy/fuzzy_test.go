@@ -0,0 +1,66 @@
+package fuzzy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "kitten", b: "sitting", expected: 3},
+		{a: "", b: "map", expected: 3},
+		{a: "pikachu", b: "pikachu", expected: 0},
+		{a: "pikachuu", b: "pikachu", expected: 1},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if actual := Distance(c.a, c.b); actual != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestClosest(t *testing.T) {
+	commands := []string{"catch", "explore", "inspect", "map", "mapb"}
+
+	if closest, found := Closest("explor", commands); !found || closest != "explore" {
+		t.Errorf("expected explore, got %q", closest)
+	}
+	if closest, found := Closest("mpa", commands); !found || closest != "map" {
+		t.Errorf("expected map, got %q", closest)
+	}
+	if closest, found := Closest("battle", commands); found {
+		t.Errorf("expected no match, got %q", closest)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	names := []string{"pikachu", "pichu", "raichu", "canalave-city-area", "eterna-city-area", "eterna-forest-area"}
+
+	cases := []struct {
+		name     string
+		expected []string
+	}{
+		{name: "pikachuu", expected: []string{"pikachu"}},
+		{name: "canalave-city", expected: []string{"canalave-city-area"}},
+		{name: "eterna", expected: []string{"eterna-city-area", "eterna-forest-area"}},
+		{name: "picchu", expected: []string{"pichu", "pikachu"}},
+		{name: "mewtwo", expected: []string{}},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := Suggest(c.name, names, 3)
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rkanagy/pokedexcli/internal/term"
+)
+
+// ErrInterrupted is returned by ReadLine when the user presses Ctrl-C
+var ErrInterrupted = errors.New("interrupted")
+
+// Completer returns the candidates for completing the word at the end of
+// line, which is the text before the cursor, along with the byte offset in
+// line where that word starts
+type Completer func(line string) (start int, candidates []string)
+
+// Editor reads lines with readline-style editing, history and tab
+// completion when reading from a terminal, and plain lines otherwise
+type Editor struct {
+	History     *History
+	Completer   Completer
+	in          *bufio.Reader
+	out         io.Writer
+	fd          int
+	terminal    bool
+	showPrompts bool
+}
+
+// New creates an editor reading from in and echoing to out
+func New(in *os.File, out io.Writer) *Editor {
+	fd := int(in.Fd())
+	return &Editor{
+		History:     NewHistory(defaultHistorySize),
+		in:          bufio.NewReader(in),
+		out:         out,
+		fd:          fd,
+		terminal:    term.IsTerminal(fd),
+		showPrompts: true,
+	}
+}
+
+// NewReader creates an editor reading plain lines from in without
+// displaying prompts, for running scripts
+func NewReader(in io.Reader, out io.Writer) *Editor {
+	return &Editor{
+		History: NewHistory(defaultHistorySize),
+		in:      bufio.NewReader(in),
+		out:     out,
+		fd:      -1,
+	}
+}
+
+// IsTerminal reports whether the editor is reading from a terminal
+func (e *Editor) IsTerminal() bool {
+	return e.terminal
+}
+
+// ReadKey reads a single key press from the editor's input, for commands
+// that take over the terminal between lines.  The terminal must be in raw
+// mode.
+func (e *Editor) ReadKey() (term.Key, rune, error) {
+	return term.ReadKey(e.in)
+}
+
+// ReadLine displays the prompt and returns the next line of input without
+// its line ending.  It returns io.EOF at the end of input and
+// ErrInterrupted if the user pressed Ctrl-C.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	if !e.terminal {
+		return e.readPlainLine(prompt)
+	}
+
+	restore, err := term.MakeRaw(e.fd)
+	if err != nil {
+		return e.readPlainLine(prompt)
+	}
+	defer restore()
+
+	return e.edit(prompt)
+}
+
+func (e *Editor) readPlainLine(prompt string) (string, error) {
+	if e.showPrompts {
+		fmt.Fprint(e.out, prompt)
+	}
+
+	line, err := e.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// lineState is the line being edited and the position of the cursor
+type lineState struct {
+	prompt       string
+	buf          []rune
+	pos          int
+	historyIndex int
+	draft        []rune
+	lastKey      term.Key
+}
+
+func (e *Editor) edit(prompt string) (string, error) {
+	s := &lineState{prompt: prompt, historyIndex: len(e.History.Entries())}
+	e.refresh(s)
+
+	for {
+		k, r, err := term.ReadKey(e.in)
+		if err != nil {
+			return "", err
+		}
+		if k == term.KeyCtrlR {
+			k, r, err = e.reverseSearch(s)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		switch k {
+		case term.KeyEnter:
+			fmt.Fprint(e.out, "\r\n")
+			return string(s.buf), nil
+		case term.KeyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case term.KeyCtrlD:
+			if len(s.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			s.deleteRight()
+		case term.KeyRune:
+			s.insert([]rune{r})
+		case term.KeyTab:
+			e.complete(s)
+		case term.KeyBackspace:
+			if s.pos > 0 {
+				s.pos--
+				s.deleteRight()
+			}
+		case term.KeyDelete:
+			s.deleteRight()
+		case term.KeyLeft:
+			s.pos = max(s.pos-1, 0)
+		case term.KeyRight:
+			s.pos = min(s.pos+1, len(s.buf))
+		case term.KeyHome:
+			s.pos = 0
+		case term.KeyEnd:
+			s.pos = len(s.buf)
+		case term.KeyUp:
+			e.browseHistory(s, -1)
+		case term.KeyDown:
+			e.browseHistory(s, 1)
+		case term.KeyCtrlK:
+			s.buf = s.buf[:s.pos]
+		case term.KeyCtrlU:
+			s.buf = s.buf[s.pos:]
+			s.pos = 0
+		case term.KeyCtrlW:
+			s.deleteWordLeft()
+		case term.KeyCtrlL:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		}
+
+		s.lastKey = k
+		e.refresh(s)
+	}
+}
+
+func (e *Editor) refresh(s *lineState) {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", s.prompt, string(s.buf))
+	if back := len(s.buf) - s.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+func (s *lineState) insert(runes []rune) {
+	buf := make([]rune, 0, len(s.buf)+len(runes))
+	buf = append(buf, s.buf[:s.pos]...)
+	buf = append(buf, runes...)
+	buf = append(buf, s.buf[s.pos:]...)
+	s.buf = buf
+	s.pos += len(runes)
+}
+
+func (s *lineState) deleteRight() {
+	if s.pos < len(s.buf) {
+		s.buf = append(s.buf[:s.pos], s.buf[s.pos+1:]...)
+	}
+}
+
+func (s *lineState) deleteWordLeft() {
+	start := s.pos
+	for start > 0 && s.buf[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && s.buf[start-1] != ' ' {
+		start--
+	}
+	s.buf = append(s.buf[:start], s.buf[s.pos:]...)
+	s.pos = start
+}
+
+// browseHistory moves through the history, keeping the line being typed
+// as a draft to return to below the newest entry
+func (e *Editor) browseHistory(s *lineState, direction int) {
+	entries := e.History.Entries()
+	index := s.historyIndex + direction
+	if index < 0 || index > len(entries) {
+		return
+	}
+
+	if s.historyIndex == len(entries) {
+		s.draft = append([]rune{}, s.buf...)
+	}
+	s.historyIndex = index
+	if index == len(entries) {
+		s.buf = append([]rune{}, s.draft...)
+	} else {
+		s.buf = []rune(entries[index])
+	}
+	s.pos = len(s.buf)
+}
+
+// reverseSearch runs an incremental search backwards through the history.
+// It returns the key that ended the search so the editor can act on it;
+// the matched line replaces the line being edited unless the search was
+// cancelled.
+func (e *Editor) reverseSearch(s *lineState) (term.Key, rune, error) {
+	entries := e.History.Entries()
+	query := []rune{}
+	match := len(entries)
+	failing := false
+
+	for {
+		status := "reverse-i-search"
+		if failing {
+			status = "failing reverse-i-search"
+		}
+		line := ""
+		if match < len(entries) {
+			line = entries[match]
+		}
+		fmt.Fprintf(e.out, "\r(%s)`%s': %s\x1b[K", status, string(query), line)
+
+		k, r, err := term.ReadKey(e.in)
+		if err != nil {
+			return term.KeyUnknown, 0, err
+		}
+
+		switch k {
+		case term.KeyRune:
+			query = append(query, r)
+			failing = !e.searchFrom(&match, string(query), min(match+1, len(entries)))
+		case term.KeyBackspace:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+			}
+			failing = !e.searchFrom(&match, string(query), len(entries))
+		case term.KeyCtrlR:
+			failing = !e.searchFrom(&match, string(query), match)
+		case term.KeyCtrlG, term.KeyCtrlC:
+			return term.KeyUnknown, 0, nil
+		default:
+			if match < len(entries) {
+				s.buf = []rune(entries[match])
+				s.pos = len(s.buf)
+				s.historyIndex = match
+			}
+			if k == term.KeyEscape {
+				return term.KeyUnknown, 0, nil
+			}
+			return k, r, nil
+		}
+	}
+}
+
+// searchFrom updates match to the newest entry before the given index that
+// contains the query and reports whether one was found
+func (e *Editor) searchFrom(match *int, query string, before int) bool {
+	found := e.History.Search(query, before)
+	if found == -1 {
+		return false
+	}
+	*match = found
+	return true
+}
+
+// complete completes the word before the cursor.  A single candidate is
+// inserted in full, several candidates are completed to their common
+// prefix, and pressing tab again lists them.
+func (e *Editor) complete(s *lineState) {
+	if e.Completer == nil {
+		return
+	}
+
+	line := string(s.buf[:s.pos])
+	start, candidates := e.Completer(line)
+	if len(candidates) == 0 || start < 0 || start > len(line) || !utf8.ValidString(line[:start]) {
+		return
+	}
+	word := line[start:]
+	start = utf8.RuneCountInString(line[:start])
+
+	if len(candidates) == 1 {
+		s.buf = append(s.buf[:start:start], s.buf[s.pos:]...)
+		s.pos = start
+		s.insert([]rune(candidates[0] + " "))
+		return
+	}
+
+	prefix := commonPrefix(candidates)
+	if len(prefix) > len(word) && strings.HasPrefix(prefix, word) {
+		s.insert([]rune(prefix[len(word):]))
+		return
+	}
+
+	if s.lastKey == term.KeyTab {
+		sorted := append([]string{}, candidates...)
+		sort.Strings(sorted)
+		fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(sorted, "  "))
+	}
+}
+
+func commonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+
+	prefix := []rune(words[0])
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, string(prefix)) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return string(prefix)
+}
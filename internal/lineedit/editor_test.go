@@ -0,0 +1,124 @@
+package lineedit
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testEditor(input string, history ...string) *Editor {
+	e := &Editor{
+		History: NewHistory(defaultHistorySize),
+		in:      bufio.NewReader(strings.NewReader(input)),
+		out:     &bytes.Buffer{},
+	}
+	for _, line := range history {
+		e.History.Add(line)
+	}
+	return e
+}
+
+func TestEdit(t *testing.T) {
+	cases := []struct {
+		input    string
+		history  []string
+		expected string
+	}{
+		{input: "map\r", expected: "map"},
+		{input: "mpa\x1b[D\x1b[D\x1b[3~\x05p\r", expected: "map"},
+		{input: "xplore\x01e\x05 x\r", expected: "explore x"},
+		{input: "catch pikachu\x17pidgey\r", expected: "catch pidgey"},
+		{input: "\x1b[A\x1b[A\r", history: []string{"map", "mapb"}, expected: "map"},
+		{input: "help\x1b[A\x1b[B\r", history: []string{"map"}, expected: "help"},
+		{input: "\x12ex\r", history: []string{"explore a", "map", "catch b"}, expected: "explore a"},
+		{input: "\x12a\x12\x1b[Dx\r", history: []string{"explore a", "map", "mapb"}, expected: "maxp"},
+		{input: "abc\x12zz\x07\r", history: []string{"map"}, expected: "abc"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			line, err := testEditor(c.input, c.history...).edit("> ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if line != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, line)
+			}
+		})
+	}
+}
+
+func TestEditEndOfInput(t *testing.T) {
+	if _, err := testEditor("\x04").edit("> "); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+	if _, err := testEditor("map\x03").edit("> "); err != ErrInterrupted {
+		t.Errorf("expected ErrInterrupted, got %v", err)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	completer := func(line string) (int, []string) {
+		start := strings.LastIndex(line, " ") + 1
+		candidates := []string{}
+		for _, word := range []string{"map", "mapb", "explore", "forêt-de-jade", "forêt-noire", "forèt"} {
+			if strings.HasPrefix(word, line[start:]) {
+				candidates = append(candidates, word)
+			}
+		}
+		return start, candidates
+	}
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "ex\t\r", expected: "explore "},
+		{input: "m\t\r", expected: "map"},
+		{input: "help e\t\r", expected: "help explore "},
+		{input: "explore forê\t\r", expected: "explore forêt-"},
+		{input: "explore forêt-n\t\r", expected: "explore forêt-noire "},
+		{input: "é forê\t\r", expected: "é forêt-"},
+		{input: "f\t\r", expected: "for"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			e := testEditor(c.input)
+			e.Completer = completer
+			line, err := e.edit("> ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if line != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, line)
+			}
+		})
+	}
+}
+
+func TestHistorySaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedexcli", "history")
+
+	history := NewHistory(2)
+	for _, line := range []string{"map", "map", "", "explore a", "catch b"} {
+		history.Add(line)
+	}
+	if err := history.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewHistory(10)
+	if err := loaded.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"explore a", "catch b"}
+	if !reflect.DeepEqual(loaded.Entries(), expected) {
+		t.Errorf("expected %q, got %q", expected, loaded.Entries())
+	}
+}
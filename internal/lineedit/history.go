@@ -0,0 +1,96 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultHistorySize is the number of lines kept in the history
+const defaultHistorySize = 1000
+
+// History contains previously entered lines, oldest first
+type History struct {
+	entries []string
+	max     int
+}
+
+// NewHistory creates an empty history keeping at most max lines
+func NewHistory(max int) *History {
+	if max <= 0 {
+		max = defaultHistorySize
+	}
+	return &History{max: max}
+}
+
+// Add appends a line to the history without trailing whitespace, skipping
+// blank lines and repeats of the previous line
+func (h *History) Add(line string) {
+	line = strings.TrimRight(line, " \t")
+	if line == "" {
+		return
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == line {
+		return
+	}
+
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+}
+
+// Entries returns the lines in the history, oldest first
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Search returns the index of the newest entry before the given index that
+// contains the query, or -1 if there is none
+func (h *History) Search(query string, before int) int {
+	if before > len(h.entries) {
+		before = len(h.entries)
+	}
+	for i := before - 1; i >= 0; i-- {
+		if strings.Contains(h.entries[i], query) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Load reads the history from a file with one line per entry.  A missing
+// file is not an error.
+func (h *History) Load(path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		h.Add(scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// Save writes the history to a file with one line per entry, creating its
+// directory if needed
+func (h *History) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	content := strings.Join(h.entries, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	return os.WriteFile(path, []byte(content), 0o600)
+}
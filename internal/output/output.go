@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is the format command output is written in
+type Format string
+
+const (
+	// Text is the human readable format each command prints by default
+	Text Format = "text"
+
+	// JSON writes indented JSON
+	JSON Format = "json"
+
+	// YAML writes block style YAML
+	YAML Format = "yaml"
+
+	// CSV writes a header row followed by one row per record
+	CSV Format = "csv"
+)
+
+// Formats contains the names of the supported formats
+var Formats = []string{string(Text), string(JSON), string(YAML), string(CSV)}
+
+// Tabular is implemented by values that can be written as CSV
+type Tabular interface {
+	Table() (header []string, rows [][]string)
+}
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if strings.EqualFold(name, format) {
+			return Format(format), nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q, expected one of %s", name, strings.Join(Formats, ", "))
+}
+
+// Write writes a value in a structured format.  JSON and YAML use the
+// value's json struct tags; CSV requires the value to be Tabular.
+func Write(w io.Writer, format Format, value any) error {
+	switch format {
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case YAML:
+		return writeYAML(w, value)
+	case CSV:
+		tabular, ok := value.(Tabular)
+		if !ok {
+			return fmt.Errorf("this output cannot be written as csv")
+		}
+		header, rows := tabular.Table()
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	default:
+		return fmt.Errorf("output format %q is not a structured format", format)
+	}
+}
+
+// writeYAML converts the value to generic data through its JSON encoding,
+// so struct tags are honoured, and writes it as YAML
+func writeYAML(w io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	encodeYAML(&buf, generic, 0)
+	_, err = io.WriteString(w, buf.String())
+	return err
+}
@@ -0,0 +1,117 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+type testLocation struct {
+	Name      string   `json:"name"`
+	ID        int      `json:"id"`
+	Pokemon   []string `json:"pokemon"`
+	Next      *string  `json:"next"`
+	Empty     []string `json:"empty"`
+	Numbering string   `json:"numbering"`
+}
+
+func (l testLocation) Table() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, name := range l.Pokemon {
+		rows = append(rows, []string{l.Name, name})
+	}
+	return []string{"location", "pokemon"}, rows
+}
+
+var testValue = testLocation{
+	Name:      "canalave-city-area",
+	ID:        1,
+	Pokemon:   []string{"tentacool", "mr-mime"},
+	Empty:     []string{},
+	Numbering: "012",
+}
+
+func TestWrite(t *testing.T) {
+	cases := []struct {
+		format   Format
+		expected string
+	}{
+		{
+			format: YAML,
+			expected: `empty: []
+id: 1
+name: canalave-city-area
+next: null
+numbering: "012"
+pokemon:
+  - tentacool
+  - mr-mime
+`,
+		},
+		{
+			format:   CSV,
+			expected: "location,pokemon\ncanalave-city-area,tentacool\ncanalave-city-area,mr-mime\n",
+		},
+		{
+			format: JSON,
+			expected: `{
+  "name": "canalave-city-area",
+  "id": 1,
+  "pokemon": [
+    "tentacool",
+    "mr-mime"
+  ],
+  "next": null,
+  "empty": [],
+  "numbering": "012"
+}
+`,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, c.format, testValue); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteNestedYAML(t *testing.T) {
+	value := map[string]any{
+		"stats": []map[string]any{{"name": "hp", "base": 45}},
+	}
+	expected := `stats:
+  - base: 45
+    name: hp
+`
+
+	var buf bytes.Buffer
+	if err := Write(&buf, YAML, value); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat("JSON"); err != nil || format != JSON {
+		t.Errorf("expected json, got %q (%v)", format, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
+
+func TestWriteCSVRequiresTabular(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, []string{"a"}); err == nil {
+		t.Errorf("expected an error for a value that is not tabular")
+	}
+}
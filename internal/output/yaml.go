@@ -0,0 +1,116 @@
+package output
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// encodeYAML writes generic JSON data as block style YAML at the given
+// indentation
+func encodeYAML(buf *strings.Builder, value any, indent int) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			buf.WriteString("{}\n")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			buf.WriteString(strings.Repeat("  ", indent))
+			buf.WriteString(yamlScalar(key))
+			buf.WriteString(":")
+			writeYAMLChild(buf, v[key], indent+1)
+		}
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString("[]\n")
+			return
+		}
+		for _, item := range v {
+			buf.WriteString(strings.Repeat("  ", indent))
+			buf.WriteString("-")
+
+			// maps in lists start on the same line as the dash
+			if m, ok := item.(map[string]any); ok && len(m) > 0 {
+				var nested strings.Builder
+				encodeYAML(&nested, m, indent+1)
+				buf.WriteString(" ")
+				buf.WriteString(strings.TrimLeft(nested.String(), " "))
+				continue
+			}
+			writeYAMLChild(buf, item, indent+1)
+		}
+	default:
+		buf.WriteString(yamlScalar(v))
+		buf.WriteString("\n")
+	}
+}
+
+// writeYAMLChild writes a nested value after a "key:" or "-", inline for
+// scalars and empty collections and on the following lines otherwise
+func writeYAMLChild(buf *strings.Builder, value any, indent int) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			buf.WriteString("\n")
+			encodeYAML(buf, v, indent)
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			buf.WriteString("\n")
+			encodeYAML(buf, v, indent)
+			return
+		}
+	}
+
+	buf.WriteString(" ")
+	encodeYAML(buf, value, indent)
+}
+
+func yamlScalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		if needsQuotes(v) {
+			return strconv.Quote(v)
+		}
+		return v
+	case map[string]any:
+		return "{}"
+	case []any:
+		return "[]"
+	}
+	return ""
+}
+
+// needsQuotes reports whether a string would be read back as something
+// other than the same plain string
+func needsQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	return strings.ContainsAny(s, "\n\t") || strings.Contains(s, ": ") || strings.Contains(s, " #")
+}
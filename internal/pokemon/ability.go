@@ -0,0 +1,32 @@
+package pokemon
+
+import "encoding/json"
+
+const abilityPath string = "ability/"
+
+// GetAbility returns the ability information for the given ability name
+func (p *API) GetAbility(name string) (Ability, error) {
+	url := p.baseURL + abilityPath + name
+	body, err := p.httpGet(url)
+	if err != nil {
+		return Ability{}, err
+	}
+
+	ability := Ability{}
+	err = json.Unmarshal(body, &ability)
+	if err != nil {
+		return Ability{}, err
+	}
+
+	return ability, nil
+}
+
+// EnglishEffect returns the English effect text of the ability, if any
+func (a Ability) EnglishEffect() string {
+	for _, entry := range a.EffectEntries {
+		if entry.Language.Name == "en" {
+			return entry.Effect
+		}
+	}
+	return ""
+}
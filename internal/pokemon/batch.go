@@ -0,0 +1,134 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Result is the body fetched from a url in a batch, or the error fetching
+// it
+type Result struct {
+	URL  string
+	Body []byte
+	Err  error
+}
+
+// FetchAll fetches the urls concurrently, at most Options.Workers at a
+// time, and returns the results in the order of the urls.  Responses are
+// cached like any other, and a url requested more than once, in this batch
+// or by anything else at the same time, is only fetched once.
+func (p *API) FetchAll(urls []string) []Result {
+	return p.FetchAllContext(context.Background(), urls, nil)
+}
+
+// FetchAllContext is FetchAll for batches that can be cancelled.  Urls not
+// fetched when the context is done fail with its error.  If progress is not
+// nil it is called, one call at a time, after each url fetched or failed
+// before then with the number done so far.
+func (p *API) FetchAllContext(ctx context.Context, urls []string, progress func(done int)) []Result {
+	results := make([]Result, len(urls))
+	jobs := make(chan int)
+
+	var mu sync.Mutex
+	done := 0
+	var wg sync.WaitGroup
+	for w := 0; w < min(p.workers, len(urls)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					results[i] = Result{URL: urls[i], Err: ctx.Err()}
+					continue
+				}
+				body, err := p.httpGetContext(ctx, urls[i])
+				results[i] = Result{URL: urls[i], Body: body, Err: err}
+				if ctx.Err() != nil {
+					continue
+				}
+
+				mu.Lock()
+				done++
+				if progress != nil {
+					progress(done)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range urls {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			results[i] = Result{URL: urls[i], Err: ctx.Err()}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// fetchAllAs fetches the resources at the paths with FetchAll and decodes
+// them, in order.  Resources that could not be fetched are left zero and
+// their errors joined.
+func fetchAllAs[T any](p *API, paths []string) ([]T, error) {
+	urls := make([]string, 0, len(paths))
+	for _, path := range paths {
+		urls = append(urls, p.baseURL+path)
+	}
+
+	values := make([]T, len(urls))
+	errs := []error{}
+	for i, result := range p.FetchAll(urls) {
+		err := result.Err
+		if err == nil {
+			err = json.Unmarshal(result.Body, &values[i])
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fetching %s: %w", paths[i], err))
+		}
+	}
+	return values, errors.Join(errs...)
+}
+
+// GetPokemonBatch returns the Pokemon information for each of the names,
+// fetched concurrently
+func (p *API) GetPokemonBatch(names []string) ([]Pokemon, error) {
+	return fetchAllAs[Pokemon](p, resourcePaths(pokemonPath, names))
+}
+
+// GetPokemonSpeciesBatch returns the species information for each of the
+// names, fetched concurrently
+func (p *API) GetPokemonSpeciesBatch(names []string) ([]PokemonSpecies, error) {
+	return fetchAllAs[PokemonSpecies](p, resourcePaths(pokemonSpeciesPath, names))
+}
+
+// GetLocationAreaBatch returns the location area information for each of
+// the names, fetched concurrently
+func (p *API) GetLocationAreaBatch(names []string) ([]LocationArea, error) {
+	return fetchAllAs[LocationArea](p, resourcePaths(locationAreaPath, names))
+}
+
+// GetPokemonEncountersBatch returns the location areas where each of the
+// Pokemon can be encountered, fetched concurrently
+func (p *API) GetPokemonEncountersBatch(names []string) ([][]LocationAreaEncounter, error) {
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, fmt.Sprintf(pokemonEncountersPath, name))
+	}
+	return fetchAllAs[[]LocationAreaEncounter](p, paths)
+}
+
+// resourcePaths returns the paths of the named resources under the path
+func resourcePaths(path string, names []string) []string {
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, path+name)
+	}
+	return paths
+}
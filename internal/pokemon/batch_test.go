@@ -0,0 +1,194 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFetchAll(t *testing.T) {
+	const workers = 3
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	active, maxActive := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		if r.URL.Path == "/pokemon/missingno" {
+			http.NotFound(w, r)
+		} else {
+			fmt.Fprintf(w, `{"name": %q}`, r.URL.Path[len("/pokemon/"):])
+		}
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	api := NewAPI(Options{BaseURL: server.URL, Workers: workers})
+	names := []string{"bulbasaur", "ivysaur", "bulbasaur", "venusaur", "missingno", "charmander", "bulbasaur"}
+	pokemon, err := api.GetPokemonBatch(names)
+	if err == nil {
+		t.Errorf("expected an error for missingno")
+	}
+
+	for i, name := range names {
+		expected := name
+		if name == "missingno" {
+			expected = ""
+		}
+		if pokemon[i].Name != expected {
+			t.Errorf("result %d: expected %q, got %q", i, expected, pokemon[i].Name)
+		}
+	}
+	if requests["/pokemon/bulbasaur"] != 1 {
+		t.Errorf("expected bulbasaur to be fetched once, got %d", requests["/pokemon/bulbasaur"])
+	}
+	if maxActive > workers {
+		t.Errorf("expected at most %d requests at once, got %d", workers, maxActive)
+	}
+}
+
+func TestFetchAllContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	api := NewAPI(Options{BaseURL: server.URL, Workers: 2, RateLimit: 10})
+	ctx, cancel := context.WithCancel(context.Background())
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c", server.URL + "/d", server.URL + "/e"}
+	done := 0
+	results := api.FetchAllContext(ctx, urls, func(n int) {
+		done = n
+		if n == 2 {
+			cancel()
+		}
+	})
+
+	cancelled := 0
+	for _, result := range results {
+		if errors.Is(result.Err, context.Canceled) {
+			cancelled++
+		}
+	}
+	if done != 2 || cancelled != len(urls)-2 {
+		t.Errorf("expected 2 urls fetched and the rest cancelled, got %d fetched and %d cancelled", done, cancelled)
+	}
+}
+
+func TestFetchSharedWithCancelledCaller(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprint(w, `{"name": "bulbasaur"}`)
+	}))
+	defer server.Close()
+
+	api := NewAPI(Options{BaseURL: server.URL})
+	url := server.URL + "/pokemon/bulbasaur"
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := api.httpGetContext(ctx, url)
+		first <- err
+	}()
+	second := make(chan error)
+	go func() {
+		// give the first caller time to start the request
+		time.Sleep(20 * time.Millisecond)
+		_, err := api.httpGetContext(context.Background(), url)
+		second <- err
+	}()
+
+	time.Sleep(40 * time.Millisecond)
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled caller to stop waiting, got %v", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("expected the other caller to get the shared result, got %v", err)
+	}
+}
+
+func TestGetLocalNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon-species":
+			fmt.Fprint(w, `{"results": [{"name": "bulbasaur"}, {"name": "deoxys"}, {"name": "missingno"}]}`)
+		case "/pokemon-species/bulbasaur":
+			fmt.Fprint(w, `{"name": "bulbasaur", "names": [{"name": "Bulbizarre", "language": {"name": "fr"}}],
+				"varieties": [{"is_default": true, "pokemon": {"name": "bulbasaur"}}]}`)
+		case "/pokemon-species/deoxys":
+			fmt.Fprint(w, `{"name": "deoxys", "names": [{"name": "Deoxys", "language": {"name": "en"}}],
+				"varieties": [{"is_default": true, "pokemon": {"name": "deoxys-normal"}}]}`)
+		case "/location-area":
+			fmt.Fprint(w, `{"results": [{"name": "viridian-forest-area"}]}`)
+		case "/location-area/viridian-forest-area":
+			fmt.Fprint(w, `{"name": "viridian-forest-area", "names": [{"name": "Forêt de Jade", "language": {"name": "fr"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	api := NewAPI(Options{BaseURL: server.URL + "/"})
+	stages := make(map[string]int)
+	names, err := api.GetLocalNames(context.Background(), "fr", func(stage string, done, total int) {
+		stages[stage] = total
+	})
+	if err == nil {
+		t.Errorf("expected an error for missingno")
+	}
+
+	expected := map[string]string{"Bulbizarre": "bulbasaur", "Deoxys": "deoxys-normal", "Forêt de Jade": "viridian-forest-area"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the names that could be fetched %v, got %v", expected, names)
+	}
+	if stages["species names"] != 3 || stages["area names"] != 1 {
+		t.Errorf("expected progress for 3 species and 1 area, got %v", stages)
+	}
+}
+
+func TestFlightCancelledWithoutWaiters(t *testing.T) {
+	flights := newFlightGroup()
+	ctx, cancel := context.WithCancel(context.Background())
+	fetchDone := make(chan error, 1)
+	go func() {
+		// wait for the fetch to start before cancelling the only caller
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := flights.do(ctx, "url", func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		fetchDone <- ctx.Err()
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the caller to be cancelled, got %v", err)
+	}
+
+	select {
+	case err := <-fetchDone:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the fetch to be cancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expected the fetch to be cancelled once its only caller was")
+	}
+}
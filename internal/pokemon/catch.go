@@ -6,11 +6,11 @@ import (
 	"time"
 )
 
-const pokemonBaseURL string = "https://pokeapi.co/api/v2/pokemon/"
+const pokemonPath string = "pokemon/"
 
 // Capture captures a Pokemon based on base experience
 func (p *API) Capture(name string) (*Pokemon, error) {
-	pokemon, err := p.getPokemon(name)
+	pokemon, err := p.GetPokemon(name)
 	if err != nil {
 		return nil, err
 	}
@@ -22,8 +22,9 @@ func (p *API) Capture(name string) (*Pokemon, error) {
 	}
 }
 
-func (p *API) getPokemon(name string) (Pokemon, error) {
-	url := pokemonBaseURL + name
+// GetPokemon returns the Pokemon information for the given Pokemon name
+func (p *API) GetPokemon(name string) (Pokemon, error) {
+	url := p.baseURL + pokemonPath + name
 	body, err := p.httpGet(url)
 	if err != nil {
 		return Pokemon{}, err
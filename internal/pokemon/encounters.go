@@ -0,0 +1,26 @@
+package pokemon
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const pokemonEncountersPath string = "pokemon/%s/encounters"
+
+// GetPokemonEncounters returns the location areas where the given Pokemon,
+// by name or ID, can be encountered in the wild
+func (p *API) GetPokemonEncounters(name string) ([]LocationAreaEncounter, error) {
+	url := p.baseURL + fmt.Sprintf(pokemonEncountersPath, name)
+	body, err := p.httpGet(url)
+	if err != nil {
+		return nil, err
+	}
+
+	encounters := []LocationAreaEncounter{}
+	err = json.Unmarshal(body, &encounters)
+	if err != nil {
+		return nil, err
+	}
+
+	return encounters, nil
+}
@@ -0,0 +1,74 @@
+package pokemon
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup makes sure concurrent requests for the same url are made
+// once, with every caller getting the result of that one request
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a request in progress
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flight)}
+}
+
+// do calls fetch for the url, unless a call for it is already in progress,
+// in which case it waits for that call instead.  The call is not cancelled
+// with the context of the caller that started it, since others may be
+// waiting for it, but each caller stops waiting when its own context is
+// done, and the call is cancelled once no caller is waiting for it.
+func (g *flightGroup) do(ctx context.Context, url string, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	call, found := g.calls[url]
+	if !found {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[url] = call
+		go g.run(callCtx, url, call, fetch)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// callers arriving from now on start a new call
+			call.cancel()
+			if g.calls[url] == call {
+				delete(g.calls, url)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// run makes the call and hands its result to the callers waiting for it
+func (g *flightGroup) run(ctx context.Context, url string, call *flight, fetch func(context.Context) ([]byte, error)) {
+	call.body, call.err = fetch(ctx)
+	call.cancel()
+
+	g.mu.Lock()
+	if g.calls[url] == call {
+		delete(g.calls, url)
+	}
+	g.mu.Unlock()
+	close(call.done)
+}
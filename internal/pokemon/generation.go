@@ -0,0 +1,28 @@
+package pokemon
+
+import "encoding/json"
+
+const generationPath string = "generation/"
+
+// GetGeneration returns the generation information for the given
+// generation name or number
+func (p *API) GetGeneration(name string) (Generation, error) {
+	url := p.baseURL + generationPath + name
+	body, err := p.httpGet(url)
+	if err != nil {
+		return Generation{}, err
+	}
+
+	generation := Generation{}
+	err = json.Unmarshal(body, &generation)
+	if err != nil {
+		return Generation{}, err
+	}
+
+	return generation, nil
+}
+
+// GetGenerations returns the names and urls of all generations
+func (p *API) GetGenerations() ([]NamedAPIResource, error) {
+	return p.getAllResources("generation")
+}
@@ -0,0 +1,43 @@
+package pokemon
+
+import "encoding/json"
+
+const growthRatePath string = "growth-rate/"
+
+// GetGrowthRate returns the growth rate information for the given growth rate name
+func (p *API) GetGrowthRate(name string) (GrowthRate, error) {
+	url := p.baseURL + growthRatePath + name
+	body, err := p.httpGet(url)
+	if err != nil {
+		return GrowthRate{}, err
+	}
+
+	growthRate := GrowthRate{}
+	err = json.Unmarshal(body, &growthRate)
+	if err != nil {
+		return GrowthRate{}, err
+	}
+
+	return growthRate, nil
+}
+
+// ExperienceForLevel returns the total experience needed to reach the given level
+func (g GrowthRate) ExperienceForLevel(level int) int {
+	for _, growthRateLevel := range g.Levels {
+		if growthRateLevel.Level == level {
+			return growthRateLevel.Experience
+		}
+	}
+	return 0
+}
+
+// LevelForExperience returns the level reached with the given total experience
+func (g GrowthRate) LevelForExperience(experience int) int {
+	level := 1
+	for _, growthRateLevel := range g.Levels {
+		if growthRateLevel.Experience <= experience && growthRateLevel.Level > level {
+			level = growthRateLevel.Level
+		}
+	}
+	return level
+}
@@ -1,6 +1,7 @@
 package pokemon
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -8,16 +9,33 @@ import (
 )
 
 func (p *API) httpGet(url string) ([]byte, error) {
+	return p.httpGetContext(context.Background(), url)
+}
+
+// httpGetContext is httpGet for requests that can be cancelled
+func (p *API) httpGetContext(ctx context.Context, url string) ([]byte, error) {
 	// is the url in the cache?  If so, then get it from the cache,
-	//otherwise do an HTTP Get on the url
+	// otherwise do an HTTP Get on the url, once however many callers
+	// want it at the same time
 	body, found := p.cache.Get(url)
-	if !found {
-		resp, err := http.Get(url)
+	if found {
+		return body, nil
+	}
+
+	return p.flights.do(ctx, url, func(ctx context.Context) ([]byte, error) {
+		if err := p.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
 			return nil, err
 		}
 
-		body, err = io.ReadAll(resp.Body)
+		body, err := io.ReadAll(resp.Body)
 		resp.Body.Close()
 		if resp.StatusCode > 299 {
 			msg := fmt.Sprintf("Response failed with status code: %d and\nbody: %s\n", resp.StatusCode, body)
@@ -27,7 +45,6 @@ func (p *API) httpGet(url string) ([]byte, error) {
 			return nil, err
 		}
 		p.cache.Add(url, body)
-	}
-
-	return body, nil
+		return body, nil
+	})
 }
@@ -0,0 +1,25 @@
+package pokemon
+
+// English is the language code of English, the language names fall back to
+const English = "en"
+
+// Languages are the codes of the languages PokeAPI has names in
+var Languages = []string{"ja-Hrkt", "roomaji", "ko", "zh-Hant", "fr", "de", "es", "it", "en", "cs", "ja", "zh-Hans", "pt-BR"}
+
+// LocalName returns the name in the language, falling back to the English
+// name.  It returns false if there is neither.
+func LocalName(names []Name, language string) (string, bool) {
+	english := ""
+	for _, name := range names {
+		if name.Name == "" {
+			continue
+		}
+		if name.Language.Name == language {
+			return name.Name, true
+		}
+		if name.Language.Name == English {
+			english = name.Name
+		}
+	}
+	return english, english != ""
+}
@@ -0,0 +1,50 @@
+package pokemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// listPageSize is the number of resources requested per page when fetching
+// a complete list
+const listPageSize = 200
+
+// getAllResources follows the pages of a resource list and returns every
+// resource in it
+func (p *API) getAllResources(path string) ([]NamedAPIResource, error) {
+	resources := []NamedAPIResource{}
+	url := fmt.Sprintf("%s%s?offset=0&limit=%d", p.baseURL, path, listPageSize)
+	for url != "" {
+		body, err := p.httpGet(url)
+		if err != nil {
+			return nil, err
+		}
+
+		page := NamedAPIResourceList{}
+		err = json.Unmarshal(body, &page)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, page.Results...)
+
+		url = ""
+		if page.Next != nil {
+			url = *page.Next
+		}
+	}
+
+	return resources, nil
+}
+
+// ResourceID returns the ID at the end of a resource URL, such as 25 for
+// https://pokeapi.co/api/v2/pokemon-species/25/, or 0 if it has none
+func ResourceID(url string) int {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	id, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return 0
+	}
+	return id
+}
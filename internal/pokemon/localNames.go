@@ -0,0 +1,73 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+)
+
+// GetLocalNames returns the API names of the Pokemon and location areas by
+// their names in the language, falling back to English like LocalName.  A
+// species name stands for the default Pokemon of the species.  If progress
+// is not nil it is called as the species and location areas are fetched,
+// like for Prefetch.  Resources that could not be fetched are left out and
+// their errors joined, so the names returned may be partial; when the
+// context is done the names fetched so far are returned with its error.
+func (p *API) GetLocalNames(ctx context.Context, language string, progress PrefetchProgress) (map[string]string, error) {
+	if progress == nil {
+		progress = func(string, int, int) {}
+	}
+	names := make(map[string]string)
+	errs := []error{}
+
+	species, err := p.getAllResources("pokemon-species")
+	if err != nil {
+		return names, err
+	}
+	urls := make([]string, 0, len(species))
+	for _, resource := range species {
+		urls = append(urls, p.baseURL+pokemonSpeciesPath+resource.Name)
+	}
+	results := p.FetchAllContext(ctx, urls, func(done int) {
+		progress("species names", done, len(urls))
+	})
+	_, _, err = decodeResults(results, func(species PokemonSpecies, _ []string) []string {
+		if local, found := LocalName(species.Names, language); found {
+			names[local] = defaultPokemon(species)
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		return names, errors.Join(err, ctx.Err())
+	}
+	errs = append(errs, err)
+
+	areas, err := p.getAllResources("location-area")
+	if err != nil {
+		return names, errors.Join(append(errs, err)...)
+	}
+	urls = make([]string, 0, len(areas))
+	for _, resource := range areas {
+		urls = append(urls, p.baseURL+locationAreaPath+resource.Name)
+	}
+	results = p.FetchAllContext(ctx, urls, func(done int) {
+		progress("area names", done, len(urls))
+	})
+	_, _, err = decodeResults(results, func(area LocationArea, _ []string) []string {
+		if local, found := LocalName(area.Names, language); found {
+			names[local] = area.Name
+		}
+		return nil
+	})
+	return names, errors.Join(append(errs, err, ctx.Err())...)
+}
+
+// defaultPokemon returns the name of the default Pokemon of the species,
+// or the name of the species if it lists none
+func defaultPokemon(species PokemonSpecies) string {
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			return variety.Pokemon.Name
+		}
+	}
+	return species.Name
+}
@@ -0,0 +1,23 @@
+package pokemon
+
+import "encoding/json"
+
+const locationPath string = "location/"
+
+// GetLocation returns the location information, including its areas, for
+// the given location name
+func (p *API) GetLocation(name string) (Location, error) {
+	url := p.baseURL + locationPath + name
+	body, err := p.httpGet(url)
+	if err != nil {
+		return Location{}, err
+	}
+
+	location := Location{}
+	err = json.Unmarshal(body, &location)
+	if err != nil {
+		return Location{}, err
+	}
+
+	return location, nil
+}
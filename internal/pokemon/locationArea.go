@@ -2,11 +2,11 @@ package pokemon
 
 import "encoding/json"
 
-const locationAreaBaseURL string = "https://pokeapi.co/api/v2/location-area/"
+const locationAreaPath string = "location-area/"
 
 // GetLocationArea returns the location area information for the given locationArea name
 func (p *API) GetLocationArea(locationArea string) (LocationArea, error) {
-	url := locationAreaBaseURL + locationArea
+	url := p.baseURL + locationAreaPath + locationArea
 	body, err := p.httpGet(url)
 	if err != nil {
 		return LocationArea{}, err
@@ -3,9 +3,10 @@ package pokemon
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 )
 
-const defaultNextURL string = "https://pokeapi.co/api/v2/location-area?offset=0&limit=20"
+const locationAreasPath string = "location-area?offset=%d&limit=%d"
 
 // Config contains pointers to the next and previous URLs
 type config struct {
@@ -43,6 +44,23 @@ func (p *API) GetLocationAreas(direction int) (LocationAreas, error) {
 	return locations, nil
 }
 
+// GetLocationAreaPage returns the page of location areas starting at the
+// offset, without moving the pages returned by GetLocationAreas
+func (p *API) GetLocationAreaPage(offset int) (LocationAreas, error) {
+	body, err := p.httpGet(p.baseURL + fmt.Sprintf(locationAreasPath, offset, p.pageSize))
+	if err != nil {
+		return LocationAreas{}, err
+	}
+
+	locations := LocationAreas{}
+	err = json.Unmarshal(body, &locations)
+	if err != nil {
+		return LocationAreas{}, err
+	}
+
+	return locations, nil
+}
+
 func (p *API) getURL(direction int) (string, error) {
 	var err error
 	var url string
@@ -54,7 +72,7 @@ func (p *API) getURL(direction int) (string, error) {
 			return "", err
 		}
 	case Next:
-		url, err = getNextURL(p.config.nextURL)
+		url, err = p.getNextURL()
 		if err != nil {
 			return "", err
 		}
@@ -70,10 +88,10 @@ func (p *API) updateConfig(locations LocationAreas) {
 	p.config.previousURL = locations.Previous
 }
 
-func getNextURL(nextURL *string) (string, error) {
-	url := defaultNextURL
-	if nextURL != nil {
-		url = *nextURL
+func (p *API) getNextURL() (string, error) {
+	url := p.baseURL + fmt.Sprintf(locationAreasPath, 0, p.pageSize)
+	if p.config.nextURL != nil {
+		url = *p.config.nextURL
 	}
 	return url, nil
 }
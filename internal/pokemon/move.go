@@ -0,0 +1,22 @@
+package pokemon
+
+import "encoding/json"
+
+const movePath string = "move/"
+
+// GetMove returns the move information for the given move name
+func (p *API) GetMove(name string) (Move, error) {
+	url := p.baseURL + movePath + name
+	body, err := p.httpGet(url)
+	if err != nil {
+		return Move{}, err
+	}
+
+	move := Move{}
+	err = json.Unmarshal(body, &move)
+	if err != nil {
+		return Move{}, err
+	}
+
+	return move, nil
+}
@@ -0,0 +1,26 @@
+package pokemon
+
+// GetPokemonNames returns the names of all Pokemon, which include the forms
+// of each species such as deoxys-attack
+func (p *API) GetPokemonNames() ([]string, error) {
+	return p.getAllNames("pokemon")
+}
+
+// GetLocationAreaNames returns the names of all location areas
+func (p *API) GetLocationAreaNames() ([]string, error) {
+	return p.getAllNames("location-area")
+}
+
+// getAllNames returns the names of every resource in a resource list
+func (p *API) getAllNames(path string) ([]string, error) {
+	resources, err := p.getAllResources(path)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+	return names, nil
+}
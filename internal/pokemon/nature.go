@@ -0,0 +1,39 @@
+package pokemon
+
+import "encoding/json"
+
+const naturePath string = "nature/"
+const naturesPath string = "nature?offset=0&limit=100"
+
+// GetNature returns the nature information for the given nature name
+func (p *API) GetNature(name string) (Nature, error) {
+	url := p.baseURL + naturePath + name
+	body, err := p.httpGet(url)
+	if err != nil {
+		return Nature{}, err
+	}
+
+	nature := Nature{}
+	err = json.Unmarshal(body, &nature)
+	if err != nil {
+		return Nature{}, err
+	}
+
+	return nature, nil
+}
+
+// GetNatures returns the names and urls of all natures
+func (p *API) GetNatures() (NamedAPIResourceList, error) {
+	body, err := p.httpGet(p.baseURL + naturesPath)
+	if err != nil {
+		return NamedAPIResourceList{}, err
+	}
+
+	natures := NamedAPIResourceList{}
+	err = json.Unmarshal(body, &natures)
+	if err != nil {
+		return NamedAPIResourceList{}, err
+	}
+
+	return natures, nil
+}
@@ -6,6 +6,14 @@ type NamedAPIResource struct {
 	URL  string `json:"url"`
 }
 
+// NamedAPIResourceList contains a paginated list of named resources
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
 // LocationAreas Structures ---------------------------------------------------
 
 // LocationAreas contains the fields returned from location-area endpoint
@@ -457,3 +465,200 @@ type Stats struct {
 	Effort   int  `json:"effort"`
 	Stat     Stat `json:"stat"`
 }
+
+// ----------------------------------------------------------------------------
+
+// Ability Structures ---------------------------------------------------------
+
+// Ability contains the information for a single Ability
+type Ability struct {
+	EffectEntries []VerboseEffect  `json:"effect_entries"`
+	Generation    GenerationNR     `json:"generation"`
+	ID            int              `json:"id"`
+	IsMainSeries  bool             `json:"is_main_series"`
+	Name          string           `json:"name"`
+	Names         []Name           `json:"names"`
+	Pokemon       []AbilityPokemon `json:"pokemon"`
+}
+
+// VerboseEffect contains an effect description and its language information
+type VerboseEffect struct {
+	Effect      string     `json:"effect"`
+	Language    LanguageNR `json:"language"`
+	ShortEffect string     `json:"short_effect"`
+}
+
+// AbilityPokemon contains a Pokemon that could have an ability
+type AbilityPokemon struct {
+	IsHidden bool      `json:"is_hidden"`
+	Pokemon  PokemonNR `json:"pokemon"`
+	Slot     int       `json:"slot"`
+}
+
+// ----------------------------------------------------------------------------
+
+// Move Structures ------------------------------------------------------------
+
+// Move contains the information for a single Move
+type Move struct {
+	Accuracy     *int              `json:"accuracy"`
+	DamageClass  MoveDamageClassNR `json:"damage_class"`
+	EffectChance *int              `json:"effect_chance"`
+	Generation   GenerationNR      `json:"generation"`
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	Names        []Name            `json:"names"`
+	Power        *int              `json:"power"`
+	PP           int               `json:"pp"`
+	Priority     int               `json:"priority"`
+	Type         TypeNR            `json:"type"`
+}
+
+// MoveDamageClassNR is a Named Resource for MoveDamageClass
+type MoveDamageClassNR struct {
+	NamedAPIResource
+}
+
+// ----------------------------------------------------------------------------
+
+// Nature Structures ----------------------------------------------------------
+
+// Nature contains the information for a single Nature
+type Nature struct {
+	DecreasedStat *StatNR `json:"decreased_stat"`
+	ID            int     `json:"id"`
+	IncreasedStat *StatNR `json:"increased_stat"`
+	Name          string  `json:"name"`
+	Names         []Name  `json:"names"`
+}
+
+// StatNR is a Named Resource for Stat
+type StatNR struct {
+	NamedAPIResource
+}
+
+// ----------------------------------------------------------------------------
+
+// PokemonSpecies Structures --------------------------------------------------
+
+// PokemonSpecies contains the information for a single Pokemon Species
+type PokemonSpecies struct {
+	BaseHappiness      int                      `json:"base_happiness"`
+	CaptureRate        int                      `json:"capture_rate"`
+	EvolvesFromSpecies *PokemonSpeciesNR        `json:"evolves_from_species"`
+	Generation         GenerationNR             `json:"generation"`
+	GrowthRate         GrowthRateNR             `json:"growth_rate"`
+	ID                 int                      `json:"id"`
+	IsBaby             bool                     `json:"is_baby"`
+	IsLegendary        bool                     `json:"is_legendary"`
+	IsMythical         bool                     `json:"is_mythical"`
+	Name               string                   `json:"name"`
+	Names              []Name                   `json:"names"`
+	Order              int                      `json:"order"`
+	PokedexNumbers     []PokemonSpeciesDexEntry `json:"pokedex_numbers"`
+	Varieties          []PokemonSpeciesVariety  `json:"varieties"`
+}
+
+// GrowthRateNR is a Named Resource for GrowthRate
+type GrowthRateNR struct {
+	NamedAPIResource
+}
+
+// PokedexNR is a Named Resource for Pokedex
+type PokedexNR struct {
+	NamedAPIResource
+}
+
+// PokemonSpeciesDexEntry contains the entry number of a species in a Pokedex
+type PokemonSpeciesDexEntry struct {
+	EntryNumber int       `json:"entry_number"`
+	Pokedex     PokedexNR `json:"pokedex"`
+}
+
+// PokemonSpeciesVariety contains a Pokemon that is a variety of a species
+type PokemonSpeciesVariety struct {
+	IsDefault bool      `json:"is_default"`
+	Pokemon   PokemonNR `json:"pokemon"`
+}
+
+// ----------------------------------------------------------------------------
+
+// GrowthRate Structures ------------------------------------------------------
+
+// GrowthRate contains the information for a single Growth Rate
+type GrowthRate struct {
+	Formula string                      `json:"formula"`
+	ID      int                         `json:"id"`
+	Levels  []GrowthRateExperienceLevel `json:"levels"`
+	Name    string                      `json:"name"`
+}
+
+// GrowthRateExperienceLevel contains the experience needed to reach a level
+type GrowthRateExperienceLevel struct {
+	Experience int `json:"experience"`
+	Level      int `json:"level"`
+}
+
+// ----------------------------------------------------------------------------
+
+// Generation Structures ------------------------------------------------------
+
+// Generation contains the information for a single Generation
+type Generation struct {
+	ID             int                `json:"id"`
+	MainRegion     RegionNR           `json:"main_region"`
+	Name           string             `json:"name"`
+	Names          []Name             `json:"names"`
+	PokemonSpecies []PokemonSpeciesNR `json:"pokemon_species"`
+}
+
+// RegionNR contains the name and url of a region
+type RegionNR struct {
+	NamedAPIResource
+}
+
+// ----------------------------------------------------------------------------
+
+// Encounter Structures -------------------------------------------------------
+
+// LocationAreaEncounter contains where and in which versions a Pokemon can
+// be encountered
+type LocationAreaEncounter struct {
+	LocationArea   LocationAreaNR           `json:"location_area"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+// LocationAreaNR contains the name and url of a location area
+type LocationAreaNR struct {
+	NamedAPIResource
+}
+
+// ----------------------------------------------------------------------------
+
+// Region Structures ----------------------------------------------------------
+
+// Region contains the information for a single Region
+type Region struct {
+	ID             int                `json:"id"`
+	Locations      []LocationNR       `json:"locations"`
+	MainGeneration GenerationNR       `json:"main_generation"`
+	Name           string             `json:"name"`
+	Names          []Name             `json:"names"`
+	VersionGroups  []NamedAPIResource `json:"version_groups"`
+}
+
+// ----------------------------------------------------------------------------
+
+// Location Structures --------------------------------------------------------
+
+// Location contains the information for a single Location, such as a route
+// or a city, and the areas it is split into
+type Location struct {
+	Areas  []LocationAreaNR `json:"areas"`
+	ID     int              `json:"id"`
+	Name   string           `json:"name"`
+	Names  []Name           `json:"names"`
+	Region RegionNR         `json:"region"`
+}
+
+// ----------------------------------------------------------------------------
@@ -1,21 +1,72 @@
 package pokemon
 
 import (
+	"strings"
 	"time"
 
 	"github.com/rkanagy/pokedexcli/internal/pokecache"
 )
 
-// API contains cached responses from the Pokemon API
+const (
+	// DefaultBaseURL is the address of the public PokeAPI
+	DefaultBaseURL = "https://pokeapi.co/api/v2/"
+
+	// DefaultCacheInterval is how long responses are cached by default
+	DefaultCacheInterval = 5 * time.Minute
+
+	// DefaultPageSize is the number of location areas listed per page by
+	// default
+	DefaultPageSize = 20
+
+	// DefaultWorkers is the number of requests a batch makes at once by
+	// default
+	DefaultWorkers = 8
+)
+
+// Options configures the API; zero values take the defaults, and a zero
+// RateLimit, the number of requests started per second, means no limit
+type Options struct {
+	BaseURL       string
+	CacheInterval time.Duration
+	PageSize      int
+	Workers       int
+	RateLimit     int
+}
+
+// API contains cached responses from the Pokemon API.  Its methods may be
+// called concurrently, apart from the paging of GetLocationAreas.
 type API struct {
-	cache  pokecache.Cache
-	config config
+	cache    pokecache.Cache
+	flights  *flightGroup
+	limiter  *rateLimiter
+	config   config
+	baseURL  string
+	pageSize int
+	workers  int
 }
 
 // NewAPI creates a new Pokemon struct
-func NewAPI() API {
+func NewAPI(options Options) API {
+	if options.BaseURL == "" {
+		options.BaseURL = DefaultBaseURL
+	}
+	if options.CacheInterval <= 0 {
+		options.CacheInterval = DefaultCacheInterval
+	}
+	if options.PageSize <= 0 {
+		options.PageSize = DefaultPageSize
+	}
+	if options.Workers <= 0 {
+		options.Workers = DefaultWorkers
+	}
+
 	return API{
-		cache:  pokecache.NewCache(5 * time.Minute),
-		config: config{},
+		cache:    pokecache.NewCache(options.CacheInterval),
+		flights:  newFlightGroup(),
+		limiter:  newRateLimiter(options.RateLimit),
+		config:   config{},
+		baseURL:  strings.TrimSuffix(options.BaseURL, "/") + "/",
+		pageSize: options.PageSize,
+		workers:  options.Workers,
 	}
 }
@@ -0,0 +1,130 @@
+package pokemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PrefetchDepth is how much of the API Prefetch fetches
+type PrefetchDepth int
+
+const (
+	// PrefetchAreas fetches the pages of location areas listed by
+	// GetLocationAreas
+	PrefetchAreas PrefetchDepth = iota
+
+	// PrefetchDetails also fetches every location area
+	PrefetchDetails
+
+	// PrefetchPokemon also fetches every Pokemon found in the location
+	// areas
+	PrefetchPokemon
+)
+
+// PrefetchProgress is called as Prefetch advances with the stage it is in
+// and how many of the requests of that stage are done
+type PrefetchProgress func(stage string, done, total int)
+
+// Prefetch fills the cache with the pages of location areas and, depending
+// on the depth, the location areas and their Pokemon, so browsing them
+// later does not wait for the API.  It returns the number of responses
+// fetched or already cached.  It stops early when the context is done,
+// returning its error; other failures are joined and returned at the end.
+func (p *API) Prefetch(ctx context.Context, depth PrefetchDepth, progress PrefetchProgress) (int, error) {
+	if progress == nil {
+		progress = func(string, int, int) {}
+	}
+	errs := []error{}
+
+	// the first page tells how many pages there are
+	first := p.baseURL + fmt.Sprintf(locationAreasPath, 0, p.pageSize)
+	body, err := p.httpGetContext(ctx, first)
+	if err != nil {
+		return 0, err
+	}
+	page := LocationAreas{}
+	if err := json.Unmarshal(body, &page); err != nil {
+		return 0, err
+	}
+
+	urls := []string{first}
+	for offset := p.pageSize; offset < page.Count; offset += p.pageSize {
+		urls = append(urls, p.baseURL+fmt.Sprintf(locationAreasPath, offset, p.pageSize))
+	}
+	progress("location areas", 1, len(urls))
+	results := append([]Result{{URL: first, Body: body}}, p.FetchAllContext(ctx, urls[1:], func(done int) {
+		progress("location areas", done+1, len(urls))
+	})...)
+	fetched, areas, err := decodeResults(results, func(page LocationAreas, names []string) []string {
+		for _, result := range page.Results {
+			names = append(names, result.Name)
+		}
+		return names
+	})
+	if ctx.Err() != nil || depth < PrefetchDetails {
+		return fetched, errors.Join(append(errs, ctx.Err(), err)...)
+	}
+	errs = append(errs, err)
+
+	urls = make([]string, 0, len(areas))
+	for _, area := range areas {
+		urls = append(urls, p.baseURL+locationAreaPath+area)
+	}
+	results = p.FetchAllContext(ctx, urls, func(done int) {
+		progress("area details", done, len(urls))
+	})
+	seen := make(map[string]bool)
+	count, pokemon, err := decodeResults(results, func(area LocationArea, names []string) []string {
+		for _, encounter := range area.PokemonEncounters {
+			if !seen[encounter.Pokemon.Name] {
+				seen[encounter.Pokemon.Name] = true
+				names = append(names, encounter.Pokemon.Name)
+			}
+		}
+		return names
+	})
+	fetched += count
+	if ctx.Err() != nil || depth < PrefetchPokemon {
+		return fetched, errors.Join(append(errs, ctx.Err(), err)...)
+	}
+	errs = append(errs, err)
+
+	urls = make([]string, 0, len(pokemon))
+	for _, name := range pokemon {
+		urls = append(urls, p.baseURL+pokemonPath+name)
+	}
+	results = p.FetchAllContext(ctx, urls, func(done int) {
+		progress("pokemon", done, len(urls))
+	})
+	count, _, err = decodeResults(results, func(Pokemon, []string) []string { return nil })
+	fetched += count
+	return fetched, errors.Join(append(errs, ctx.Err(), err)...)
+}
+
+// decodeResults decodes the fetched results and collects names from them.
+// It returns the number fetched and the errors of the others, leaving out
+// those of a cancelled context, which the caller reports once.
+func decodeResults[T any](results []Result, collect func(value T, names []string) []string) (int, []string, error) {
+	fetched := 0
+	names := []string{}
+	errs := []error{}
+	for _, result := range results {
+		if errors.Is(result.Err, context.Canceled) || errors.Is(result.Err, context.DeadlineExceeded) {
+			continue
+		}
+		var value T
+		err := result.Err
+		if err == nil {
+			err = json.Unmarshal(result.Body, &value)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fetching %s: %w", result.URL, err))
+			continue
+		}
+		fetched++
+		names = collect(value, names)
+	}
+	return fetched, names, errors.Join(errs...)
+}
@@ -0,0 +1,27 @@
+package pokemon
+
+import "encoding/json"
+
+const regionPath string = "region/"
+
+// GetRegion returns the region information for the given region name
+func (p *API) GetRegion(name string) (Region, error) {
+	url := p.baseURL + regionPath + name
+	body, err := p.httpGet(url)
+	if err != nil {
+		return Region{}, err
+	}
+
+	region := Region{}
+	err = json.Unmarshal(body, &region)
+	if err != nil {
+		return Region{}, err
+	}
+
+	return region, nil
+}
+
+// GetRegions returns the names and urls of all regions
+func (p *API) GetRegions() ([]NamedAPIResource, error) {
+	return p.getAllResources("region")
+}
@@ -0,0 +1,54 @@
+package pokemon
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const pokemonSpeciesPath string = "pokemon-species/"
+
+// GetPokemonSpecies returns the species information for the given species name
+func (p *API) GetPokemonSpecies(name string) (PokemonSpecies, error) {
+	url := p.baseURL + pokemonSpeciesPath + name
+	body, err := p.httpGet(url)
+	if err != nil {
+		return PokemonSpecies{}, err
+	}
+
+	species := PokemonSpecies{}
+	err = json.Unmarshal(body, &species)
+	if err != nil {
+		return PokemonSpecies{}, err
+	}
+
+	return species, nil
+}
+
+// GetPokemonSpeciesList returns the names and urls of every Pokemon species
+func (p *API) GetPokemonSpeciesList() ([]NamedAPIResource, error) {
+	return p.getAllResources("pokemon-species")
+}
+
+// GenerationNumber returns the number of a generation from its name, such
+// as 3 for generation-iii, or 0 if the name is not a generation
+func GenerationNumber(name string) int {
+	numeral, found := strings.CutPrefix(name, "generation-")
+	if !found {
+		return 0
+	}
+
+	values := map[rune]int{'i': 1, 'v': 5, 'x': 10}
+	number := 0
+	for i, r := range numeral {
+		value := values[r]
+		if value == 0 {
+			return 0
+		}
+		if i+1 < len(numeral) && values[rune(numeral[i+1])] > value {
+			number -= value
+		} else {
+			number += value
+		}
+	}
+	return number
+}
@@ -0,0 +1,42 @@
+package pokemon
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+)
+
+// SpriteURL returns the URL of the front or back, default or shiny sprite,
+// or an empty string if the Pokemon has no such sprite
+func (s PokemonSprites) SpriteURL(shiny bool, back bool) string {
+	switch {
+	case back && shiny:
+		return s.BackShiny
+	case back:
+		return s.BackDefault
+	case shiny:
+		return s.FrontShiny
+	default:
+		return s.FrontDefault
+	}
+}
+
+// GetSprite downloads and decodes the PNG sprite at the given URL
+func (p *API) GetSprite(url string) (image.Image, error) {
+	if url == "" {
+		return nil, errors.New("no sprite available")
+	}
+
+	body, err := p.httpGet(url)
+	if err != nil {
+		return nil, err
+	}
+
+	sprite, err := png.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return sprite, nil
+}
@@ -0,0 +1,324 @@
+package query
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Record is something a query is matched against
+type Record interface {
+	// Values returns the values of a field.  A comparison matches if any
+	// of the values does, so fields such as types can have several.
+	Values(field string) ([]string, error)
+}
+
+// Predicate reports whether a record matches a query
+type Predicate interface {
+	Match(r Record) (bool, error)
+}
+
+// All is the predicate matching every record, used for an empty query
+var All Predicate = allPredicate{}
+
+type allPredicate struct{}
+
+func (allPredicate) Match(r Record) (bool, error) {
+	return true, nil
+}
+
+type andPredicate []Predicate
+
+func (a andPredicate) Match(r Record) (bool, error) {
+	for _, p := range a {
+		matched, err := p.Match(r)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+type orPredicate []Predicate
+
+func (o orPredicate) Match(r Record) (bool, error) {
+	for _, p := range o {
+		matched, err := p.Match(r)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
+
+type notPredicate struct {
+	Predicate
+}
+
+func (n notPredicate) Match(r Record) (bool, error) {
+	matched, err := n.Predicate.Match(r)
+	return !matched, err
+}
+
+// comparison compares the values of a field with a value.  Equality
+// ignores case and accepts * and ? wildcards; the ordering operators
+// compare numbers.
+type comparison struct {
+	field string
+	op    string
+	value string
+}
+
+func (c comparison) Match(r Record) (bool, error) {
+	values, err := r.Values(c.field)
+	if err != nil {
+		return false, err
+	}
+
+	if c.op == "!=" {
+		for _, value := range values {
+			if equal(value, c.value) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	for _, value := range values {
+		if c.op == "=" {
+			if equal(value, c.value) {
+				return true, nil
+			}
+			continue
+		}
+
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		target, _ := strconv.ParseFloat(c.value, 64)
+		if c.op == "<" && number < target || c.op == "<=" && number <= target ||
+			c.op == ">" && number > target || c.op == ">=" && number >= target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func equal(value, pattern string) bool {
+	value, pattern = strings.ToLower(value), strings.ToLower(pattern)
+	if a, err := strconv.ParseFloat(value, 64); err == nil {
+		if b, err := strconv.ParseFloat(pattern, 64); err == nil {
+			return a == b
+		}
+	}
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched || value == pattern
+}
+
+// Compare returns a comparison of a field with a value, for building
+// queries from flags
+func Compare(field, op, value string) Predicate {
+	return comparison{field: field, op: op, value: value}
+}
+
+// And returns a predicate matching records that match all the predicates
+func And(predicates ...Predicate) Predicate {
+	return andPredicate(predicates)
+}
+
+// Parse parses a query into a predicate.  A query is made of comparisons
+// such as type=fire, speed>=100 or name:char*, using the operators =, :,
+// !=, <, <=, > and >=, which can be combined with and, or, not and
+// parentheses.  Comparisons next to each other must all match.  Only the
+// given fields may be compared.
+func Parse(text string, fields []string) (Predicate, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return All, nil
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+	predicate, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q in query", p.peek())
+	}
+	return predicate, nil
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+	fields []string
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *parser) parseOr() (Predicate, error) {
+	predicates := orPredicate{}
+	for {
+		predicate, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+		if !strings.EqualFold(p.peek(), "or") {
+			break
+		}
+		p.next()
+	}
+
+	if len(predicates) == 1 {
+		return predicates[0], nil
+	}
+	return predicates, nil
+}
+
+func (p *parser) parseAnd() (Predicate, error) {
+	predicates := andPredicate{}
+	for {
+		predicate, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+
+		if strings.EqualFold(p.peek(), "and") {
+			p.next()
+			continue
+		}
+		if p.done() || p.peek() == ")" || strings.EqualFold(p.peek(), "or") {
+			break
+		}
+	}
+
+	if len(predicates) == 1 {
+		return predicates[0], nil
+	}
+	return predicates, nil
+}
+
+func (p *parser) parseUnary() (Predicate, error) {
+	switch token := p.next(); {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of query")
+	case strings.EqualFold(token, "not"):
+		predicate, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notPredicate{predicate}, nil
+	case token == "(":
+		predicate, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing ) in query")
+		}
+		return predicate, nil
+	default:
+		return p.parseComparison(token)
+	}
+}
+
+func (p *parser) parseComparison(field string) (Predicate, error) {
+	field = strings.ToLower(field)
+	if isOperator(field) || field == ")" {
+		return nil, fmt.Errorf("unexpected %q in query", field)
+	}
+	if !p.isField(field) {
+		return nil, fmt.Errorf("unknown field %q in query, expected one of %s", field, strings.Join(p.fields, ", "))
+	}
+
+	op := p.next()
+	if !isOperator(op) {
+		return nil, fmt.Errorf("expected an operator after %q in query", field)
+	}
+	if op == ":" {
+		op = "="
+	}
+
+	value := p.next()
+	if value == "" || isOperator(value) || value == "(" || value == ")" {
+		return nil, fmt.Errorf("expected a value after %s%s in query", field, op)
+	}
+	if op != "=" && op != "!=" {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("%s%s%s compares a number, %q is not a number", field, op, value, value)
+		}
+	}
+
+	return comparison{field: field, op: op, value: value}, nil
+}
+
+func (p *parser) isField(name string) bool {
+	for _, field := range p.fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isOperator(token string) bool {
+	switch token {
+	case "=", ":", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// lex splits a query into words, operators and parentheses
+func lex(text string) ([]string, error) {
+	tokens := []string{}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')' || c == '=' || c == ':':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '!' || c == '<' || c == '>':
+			if i+1 < len(text) && text[i+1] == '=' {
+				tokens = append(tokens, text[i:i+2])
+				i += 2
+			} else if c == '!' {
+				return nil, fmt.Errorf("expected != in query")
+			} else {
+				tokens = append(tokens, string(c))
+				i++
+			}
+		default:
+			start := i
+			for i < len(text) && !strings.ContainsRune(" \t()=:!<>", rune(text[i])) {
+				i++
+			}
+			tokens = append(tokens, text[start:i])
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,81 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+)
+
+type testRecord map[string][]string
+
+func (r testRecord) Values(field string) ([]string, error) {
+	return r[field], nil
+}
+
+var testFields = []string{"name", "type", "speed", "generation"}
+
+var charizard = testRecord{
+	"name":       {"charizard"},
+	"type":       {"fire", "flying"},
+	"speed":      {"100"},
+	"generation": {"1"},
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected bool
+	}{
+		{query: "", expected: true},
+		{query: "type=fire", expected: true},
+		{query: "type:flying", expected: true},
+		{query: "TYPE = Water", expected: false},
+		{query: "type!=water", expected: true},
+		{query: "type!=fire", expected: false},
+		{query: "speed>=100", expected: true},
+		{query: "speed>100", expected: false},
+		{query: "speed<120 generation=1", expected: true},
+		{query: "speed<120 and generation=3", expected: false},
+		{query: "generation=3 or type=fire", expected: true},
+		{query: "not type=fire", expected: false},
+		{query: "name=char*", expected: true},
+		{query: "(type=water or type=flying) and not speed<50", expected: true},
+		{query: "generation=01", expected: true},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			predicate, err := Parse(c.query, testFields)
+			if err != nil {
+				t.Fatalf("parsing %q: %v", c.query, err)
+			}
+			actual, err := predicate.Match(charizard)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != c.expected {
+				t.Errorf("%q: expected %v, actual %v", c.query, c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []string{
+		"colour=red",
+		"type",
+		"type=",
+		"speed>=fast",
+		"(type=fire",
+		"type=fire)",
+		"type ! fire",
+		"and type=fire",
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			if _, err := Parse(c, testFields); err == nil {
+				t.Errorf("expected an error parsing %q", c)
+			}
+		})
+	}
+}
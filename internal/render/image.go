@@ -0,0 +1,118 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// asciiRamp contains the characters used for pixels in the ASCII fallback,
+// from the lightest to the darkest
+const asciiRamp = ".:-=+*#%@"
+
+// alphaThreshold is the alpha at or below which a pixel is transparent
+const alphaThreshold = 0x7fff
+
+// Image draws the image scaled down to fit the renderer's width.  With
+// colors enabled each character shows two pixels as a truecolor half
+// block; otherwise each character shows the brightness of two pixels.
+// Transparent borders are trimmed first.
+func (r *Renderer) Image(w io.Writer, img image.Image) {
+	bounds := opaqueBounds(img)
+	if bounds.Empty() {
+		return
+	}
+
+	// each character is two pixels tall, so the height is rounded up to
+	// an even number of pixels
+	width := min(bounds.Dx(), r.Width)
+	height := (bounds.Dy()*width/bounds.Dx() + 1) &^ 1
+	pixel := func(x, y int) color.Color {
+		return img.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height)
+	}
+
+	for y := 0; y < height; y += 2 {
+		var line strings.Builder
+		for x := 0; x < width; x++ {
+			top, bottom := pixel(x, y), pixel(x, y+1)
+			if r.Color {
+				line.WriteString(halfBlock(top, bottom))
+			} else {
+				line.WriteByte(asciiPixel(top, bottom))
+			}
+		}
+		fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+	}
+}
+
+// halfBlock returns an upper half block colored with the top pixel in the
+// foreground and the bottom pixel in the background
+func halfBlock(top, bottom color.Color) string {
+	topOpaque, bottomOpaque := isOpaque(top), isOpaque(bottom)
+	switch {
+	case topOpaque && bottomOpaque:
+		return foreground(top) + background(bottom) + "▀" + reset
+	case topOpaque:
+		return foreground(top) + "▀" + reset
+	case bottomOpaque:
+		return foreground(bottom) + "▄" + reset
+	default:
+		return " "
+	}
+}
+
+// asciiPixel returns the character for the average brightness of the
+// opaque pixels, or a space if both are transparent
+func asciiPixel(pixels ...color.Color) byte {
+	total, opaque := 0, 0
+	for _, pixel := range pixels {
+		if isOpaque(pixel) {
+			gray := color.GrayModel.Convert(pixel).(color.Gray)
+			total += int(gray.Y)
+			opaque++
+		}
+	}
+	if opaque == 0 {
+		return ' '
+	}
+
+	darkness := 255 - total/opaque
+	return asciiRamp[darkness*len(asciiRamp)/256]
+}
+
+func foreground(c color.Color) string {
+	red, green, blue := rgb(c)
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", red, green, blue)
+}
+
+func background(c color.Color) string {
+	red, green, blue := rgb(c)
+	return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", red, green, blue)
+}
+
+func rgb(c color.Color) (uint8, uint8, uint8) {
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return nrgba.R, nrgba.G, nrgba.B
+}
+
+func isOpaque(c color.Color) bool {
+	_, _, _, alpha := c.RGBA()
+	return alpha > alphaThreshold
+}
+
+// opaqueBounds returns the smallest rectangle containing every opaque pixel
+// of the image
+func opaqueBounds(img image.Image) image.Rectangle {
+	bounds := img.Bounds()
+	opaque := image.Rectangle{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if isOpaque(img.At(x, y)) {
+				opaque = opaque.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return opaque
+}
@@ -0,0 +1,260 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rkanagy/pokedexcli/internal/term"
+)
+
+// DefaultWidth is the width used when the terminal size cannot be detected
+const DefaultWidth = 80
+
+// MaxStat is the highest possible base stat, which stat bars are scaled to
+const MaxStat = 255
+
+const (
+	reset   = "\x1b[0m"
+	bold    = "\x1b[1m"
+	dim     = "\x1b[2m"
+	reverse = "\x1b[7m"
+)
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// barBlocks are the partial blocks used to draw the end of a stat bar in
+// eighths of a character
+var barBlocks = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// typeColors are the RGB colors the games use for each Pokemon type
+var typeColors = map[string][3]int{
+	"normal":   {168, 167, 122},
+	"fire":     {238, 129, 48},
+	"water":    {99, 144, 240},
+	"electric": {247, 208, 44},
+	"grass":    {122, 199, 76},
+	"ice":      {150, 217, 214},
+	"fighting": {194, 46, 40},
+	"poison":   {163, 62, 161},
+	"ground":   {226, 191, 101},
+	"flying":   {169, 143, 243},
+	"psychic":  {249, 85, 135},
+	"bug":      {166, 185, 26},
+	"rock":     {182, 161, 54},
+	"ghost":    {115, 87, 151},
+	"dragon":   {111, 53, 252},
+	"dark":     {112, 87, 70},
+	"steel":    {183, 183, 206},
+	"fairy":    {214, 133, 173},
+}
+
+// Renderer formats text for a terminal, adding colors only when the output
+// supports them.  Terminal reports whether the output is a terminal, where
+// lines such as progress bars can be redrawn.
+type Renderer struct {
+	Color    bool
+	Terminal bool
+	Width    int
+}
+
+// New returns a renderer for the given output file.  Colors are disabled
+// when the file is not a terminal or the NO_COLOR environment variable is
+// set, and the width falls back to $COLUMNS and then DefaultWidth when the
+// terminal size is unknown.
+func New(out *os.File) *Renderer {
+	fd := int(out.Fd())
+	renderer := &Renderer{
+		Color:    term.IsTerminal(fd) && os.Getenv("NO_COLOR") == "",
+		Terminal: term.IsTerminal(fd),
+		Width:    DefaultWidth,
+	}
+
+	if width, _, err := term.Size(fd); err == nil && width > 0 {
+		renderer.Width = width
+	} else if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		renderer.Width = columns
+	}
+
+	return renderer
+}
+
+// Bold returns the text in bold
+func (r *Renderer) Bold(text string) string {
+	return r.style(bold, text)
+}
+
+// Dim returns the text in a faint color
+func (r *Renderer) Dim(text string) string {
+	return r.style(dim, text)
+}
+
+// Reverse returns the text with its foreground and background colors
+// swapped, for highlighting a selection
+func (r *Renderer) Reverse(text string) string {
+	return r.style(reverse, text)
+}
+
+// Type returns the name of a Pokemon type in that type's color
+func (r *Renderer) Type(name string) string {
+	color, found := typeColors[name]
+	if !found {
+		return name
+	}
+	return r.style(fmt.Sprintf("\x1b[38;2;%d;%d;%dm", color[0], color[1], color[2]), name)
+}
+
+// StatBar returns a bar of at most the given width whose length is the stat
+// scaled to MaxStat, colored from red for low stats to cyan for high ones
+func (r *Renderer) StatBar(stat, width int) string {
+	stat = max(0, min(stat, MaxStat))
+	eighths := stat * width * 8 / MaxStat
+	bar := strings.Repeat("█", eighths/8) + barBlocks[eighths%8]
+
+	var color string
+	switch {
+	case stat < 50:
+		color = "\x1b[31m"
+	case stat < 80:
+		color = "\x1b[33m"
+	case stat < 120:
+		color = "\x1b[32m"
+	default:
+		color = "\x1b[36m"
+	}
+	return r.style(color, bar)
+}
+
+// ProgressBar returns a bar of the given width filled in proportion to done
+// out of total, padded with spaces so it can be redrawn in place
+func (r *Renderer) ProgressBar(done, total, width int) string {
+	eighths := width * 8
+	if total > 0 {
+		eighths = max(0, min(done, total)) * width * 8 / total
+	}
+	bar := strings.Repeat("█", eighths/8) + barBlocks[eighths%8]
+	return r.style(dim, "▕") + bar + strings.Repeat(" ", width-utf8.RuneCountInString(bar)) + r.style(dim, "▏")
+}
+
+func (r *Renderer) style(code, text string) string {
+	if !r.Color || text == "" {
+		return text
+	}
+	return code + text + reset
+}
+
+// Table writes rows in aligned columns separated by two spaces.  Columns
+// whose cells are all numbers are right aligned.  The header is skipped
+// when empty and is written in bold.
+func (r *Renderer) Table(w io.Writer, header []string, rows [][]string) {
+	widths := columnWidths(header, rows)
+	numeric := numericColumns(rows, len(widths))
+
+	if len(header) > 0 {
+		cells := make([]string, len(header))
+		for i, cell := range header {
+			cells[i] = r.Bold(cell)
+		}
+		writeRow(w, cells, widths, numeric)
+	}
+	for _, row := range rows {
+		writeRow(w, row, widths, numeric)
+	}
+}
+
+// TableWidth returns the number of characters the widest line of the table
+// written by Table takes up
+func TableWidth(header []string, rows [][]string) int {
+	widths := columnWidths(header, rows)
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+	if len(widths) > 1 {
+		total += 2 * (len(widths) - 1)
+	}
+	return total
+}
+
+// VisibleWidth returns the number of characters the text takes up on the
+// terminal, ignoring color escape sequences
+func VisibleWidth(text string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(text, ""))
+}
+
+// Truncate shortens the text to at most the given number of visible
+// characters, keeping its color escape sequences
+func Truncate(text string, width int) string {
+	if VisibleWidth(text) <= width {
+		return text
+	}
+
+	var truncated strings.Builder
+	visible := 0
+	for len(text) > 0 && visible < width {
+		if escape := ansiEscape.FindStringIndex(text); escape != nil && escape[0] == 0 {
+			truncated.WriteString(text[:escape[1]])
+			text = text[escape[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text)
+		truncated.WriteRune(r)
+		text = text[size:]
+		visible++
+	}
+	if strings.Contains(truncated.String(), "\x1b[") {
+		truncated.WriteString(reset)
+	}
+	return truncated.String()
+}
+
+func columnWidths(header []string, rows [][]string) []int {
+	var widths []int
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], VisibleWidth(cell))
+		}
+	}
+	return widths
+}
+
+func numericColumns(rows [][]string, columns int) []bool {
+	numeric := make([]bool, columns)
+	for i := range numeric {
+		numeric[i] = len(rows) > 0
+	}
+	for _, row := range rows {
+		for i := range numeric {
+			if i >= len(row) {
+				continue
+			}
+			if _, err := strconv.ParseFloat(ansiEscape.ReplaceAllString(row[i], ""), 64); err != nil {
+				numeric[i] = false
+			}
+		}
+	}
+	return numeric
+}
+
+func writeRow(w io.Writer, cells []string, widths []int, numeric []bool) {
+	var line strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			line.WriteString("  ")
+		}
+		padding := strings.Repeat(" ", widths[i]-VisibleWidth(cell))
+		if numeric[i] {
+			line.WriteString(padding + cell)
+		} else {
+			line.WriteString(cell + padding)
+		}
+	}
+	fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+}
@@ -0,0 +1,170 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	plain := &Renderer{Width: DefaultWidth}
+	colored := &Renderer{Color: true, Width: DefaultWidth}
+
+	cases := []struct {
+		renderer *Renderer
+		header   []string
+		rows     [][]string
+		expected string
+	}{
+		{
+			renderer: plain,
+			header:   []string{"stat", "value"},
+			rows:     [][]string{{"hp", "45"}, {"special-attack", "100"}},
+			expected: "stat            value\n" +
+				"hp                 45\n" +
+				"special-attack    100\n",
+		},
+		{
+			renderer: plain,
+			rows:     [][]string{{"Name:", "bulbasaur"}, {"Level:", "5"}, {"Types:"}},
+			expected: "Name:   bulbasaur\n" +
+				"Level:  5\n" +
+				"Types:\n",
+		},
+		{
+			renderer: colored,
+			rows:     [][]string{{colored.Type("grass"), "1"}, {"fire", "10"}},
+			expected: "\x1b[38;2;122;199;76mgrass\x1b[0m   1\n" +
+				"fire   10\n",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			var buffer bytes.Buffer
+			c.renderer.Table(&buffer, c.header, c.rows)
+			if buffer.String() != c.expected {
+				t.Errorf("expected:\n%q\nactual:\n%q", c.expected, buffer.String())
+			}
+		})
+	}
+}
+
+func TestTableWidth(t *testing.T) {
+	width := TableWidth([]string{"stat", "value"}, [][]string{{"special-attack", "100"}})
+	if width != 21 {
+		t.Errorf("expected width 21, actual %d", width)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{text: "bulbasaur", width: 20, expected: "bulbasaur"},
+		{text: "bulbasaur", width: 4, expected: "bulb"},
+		{text: "██▌", width: 2, expected: "██"},
+		{text: "\x1b[1mbulbasaur\x1b[0m", width: 4, expected: "\x1b[1mbulb\x1b[0m"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := Truncate(c.text, c.width)
+			if actual != c.expected {
+				t.Errorf("expected %q, actual %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStatBar(t *testing.T) {
+	renderer := &Renderer{Width: DefaultWidth}
+
+	cases := []struct {
+		stat     int
+		width    int
+		expected string
+	}{
+		{stat: 255, width: 10, expected: "██████████"},
+		{stat: 0, width: 10, expected: ""},
+		{stat: 51, width: 10, expected: "██"},
+		{stat: 64, width: 10, expected: "██▌"},
+		{stat: 300, width: 4, expected: "████"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := renderer.StatBar(c.stat, c.width)
+			if actual != c.expected {
+				t.Errorf("expected %q, actual %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	renderer := &Renderer{Width: DefaultWidth}
+
+	cases := []struct {
+		done     int
+		total    int
+		expected string
+	}{
+		{done: 0, total: 10, expected: "▕          ▏"},
+		{done: 10, total: 10, expected: "▕██████████▏"},
+		{done: 5, total: 20, expected: "▕██▌       ▏"},
+		{done: 0, total: 0, expected: "▕██████████▏"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := renderer.ProgressBar(c.done, c.total, 10)
+			if actual != c.expected {
+				t.Errorf("expected %q, actual %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestColorDisabled(t *testing.T) {
+	renderer := &Renderer{Width: DefaultWidth}
+	if actual := renderer.Type("fire") + renderer.Bold("name") + renderer.StatBar(255, 1); actual != "firename█" {
+		t.Errorf("expected no escape sequences, actual %q", actual)
+	}
+}
+
+func TestImage(t *testing.T) {
+	// a 4x4 image with a transparent border around a black pixel above a
+	// white one
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 1, color.Black)
+	img.Set(1, 2, color.White)
+
+	cases := []struct {
+		renderer *Renderer
+		expected string
+	}{
+		{
+			renderer: &Renderer{Width: DefaultWidth},
+			expected: "+\n",
+		},
+		{
+			renderer: &Renderer{Color: true, Width: DefaultWidth},
+			expected: "\x1b[38;2;0;0;0m\x1b[48;2;255;255;255m▀\x1b[0m\n",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			var buffer bytes.Buffer
+			c.renderer.Image(&buffer, img)
+			if buffer.String() != c.expected {
+				t.Errorf("expected %q, actual %q", c.expected, buffer.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package term
+
+import "bufio"
+
+// Key is a key press read from the terminal
+type Key int
+
+// The keys ReadKey tells apart.  As in readline, Ctrl-A, Ctrl-E, Ctrl-B,
+// Ctrl-F, Ctrl-P and Ctrl-N are read as KeyHome, KeyEnd, KeyLeft, KeyRight,
+// KeyUp and KeyDown.
+const (
+	// KeyRune is a printable character, returned along with the key
+	KeyRune Key = iota
+
+	// the editing and movement keys
+	KeyEnter
+	KeyTab
+	KeyBackspace
+	KeyDelete
+	KeyLeft
+	KeyRight
+	KeyUp
+	KeyDown
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+
+	// the control keys with a meaning of their own
+	KeyCtrlC
+	KeyCtrlD
+	KeyCtrlG
+	KeyCtrlK
+	KeyCtrlL
+	KeyCtrlR
+	KeyCtrlU
+	KeyCtrlW
+
+	// KeyEscape is the escape key pressed on its own
+	KeyEscape
+
+	// KeyUnknown is any other key or escape sequence
+	KeyUnknown
+)
+
+// ReadKey reads a single key press, decoding escape sequences for the
+// arrow, home, end, delete and page keys
+func ReadKey(in *bufio.Reader) (Key, rune, error) {
+	r, _, err := in.ReadRune()
+	if err != nil {
+		return KeyUnknown, 0, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return KeyEnter, r, nil
+	case '\t':
+		return KeyTab, r, nil
+	case 127, 8:
+		return KeyBackspace, r, nil
+	case 1:
+		return KeyHome, r, nil
+	case 2:
+		return KeyLeft, r, nil
+	case 3:
+		return KeyCtrlC, r, nil
+	case 4:
+		return KeyCtrlD, r, nil
+	case 5:
+		return KeyEnd, r, nil
+	case 6:
+		return KeyRight, r, nil
+	case 7:
+		return KeyCtrlG, r, nil
+	case 11:
+		return KeyCtrlK, r, nil
+	case 12:
+		return KeyCtrlL, r, nil
+	case 14:
+		return KeyDown, r, nil
+	case 16:
+		return KeyUp, r, nil
+	case 18:
+		return KeyCtrlR, r, nil
+	case 21:
+		return KeyCtrlU, r, nil
+	case 23:
+		return KeyCtrlW, r, nil
+	case 27:
+		return readEscapeSequence(in)
+	}
+
+	if r < 32 {
+		return KeyUnknown, r, nil
+	}
+	return KeyRune, r, nil
+}
+
+// readEscapeSequence decodes the rest of an escape sequence.  A lone
+// escape, with nothing else waiting to be read, is the escape key itself.
+func readEscapeSequence(in *bufio.Reader) (Key, rune, error) {
+	if in.Buffered() == 0 {
+		return KeyEscape, 27, nil
+	}
+
+	introducer, err := in.ReadByte()
+	if err != nil {
+		return KeyUnknown, 0, err
+	}
+	if introducer != '[' && introducer != 'O' {
+		return KeyUnknown, 0, nil
+	}
+
+	// parameters are digits and semicolons, ended by a final byte
+	params := []byte{}
+	for {
+		b, err := in.ReadByte()
+		if err != nil {
+			return KeyUnknown, 0, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			return escapeKey(string(params), b), 0, nil
+		}
+		params = append(params, b)
+	}
+}
+
+func escapeKey(params string, final byte) Key {
+	switch final {
+	case 'A':
+		return KeyUp
+	case 'B':
+		return KeyDown
+	case 'C':
+		return KeyRight
+	case 'D':
+		return KeyLeft
+	case 'H':
+		return KeyHome
+	case 'F':
+		return KeyEnd
+	case '~':
+		switch params {
+		case "1", "7":
+			return KeyHome
+		case "4", "8":
+			return KeyEnd
+		case "3":
+			return KeyDelete
+		case "5":
+			return KeyPageUp
+		case "6":
+			return KeyPageDown
+		}
+	}
+	return KeyUnknown
+}
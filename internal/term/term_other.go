@@ -0,0 +1,23 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package term
+
+import "errors"
+
+// IsTerminal reports whether the file descriptor refers to a terminal.
+// Terminals are only supported on Unix-like platforms, so on others no file
+// descriptor is treated as one.
+func IsTerminal(fd int) bool {
+	return false
+}
+
+// MakeRaw always fails, raw mode is only supported on Unix-like platforms
+func MakeRaw(fd int) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
+
+// Size always fails, the terminal size is only known on Unix-like
+// platforms
+func Size(fd int) (int, int, error) {
+	return 0, 0, errors.New("terminal size is not supported on this platform")
+}
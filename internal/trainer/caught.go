@@ -0,0 +1,112 @@
+package trainer
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/rkanagy/pokedexcli/internal/battle"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// maxIV is the highest individual value of a stat
+const maxIV = 31
+
+// maxEV is the highest effort value of a single stat
+const maxEV = 252
+
+// maxTotalEV is the highest sum of all effort values
+const maxTotalEV = 510
+
+// CaughtPokemon is an individual Pokemon caught by the trainer
+type CaughtPokemon struct {
+	ID         int
+	Species    pokemon.Pokemon
+	Nickname   string
+	Level      int
+	Experience int
+	IVs        battle.Stats
+	EVs        battle.Stats
+	Nature     pokemon.Nature
+	GrowthRate pokemon.GrowthRate
+	CaughtAt   string
+	CaughtOn   time.Time
+}
+
+// NewCaughtPokemon creates an individual of the given species at the given
+// level with random individual values and no effort values
+func NewCaughtPokemon(species pokemon.Pokemon, level int, nature pokemon.Nature, growthRate pokemon.GrowthRate, rng *rand.Rand) CaughtPokemon {
+	ivs := battle.Stats{}
+	for _, name := range battle.StatNames {
+		ivs.Set(name, rng.Intn(maxIV+1))
+	}
+
+	return CaughtPokemon{
+		Species:    species,
+		Level:      level,
+		Experience: growthRate.ExperienceForLevel(level),
+		IVs:        ivs,
+		Nature:     nature,
+		GrowthRate: growthRate,
+		CaughtOn:   time.Now(),
+	}
+}
+
+// Name returns the name the Pokemon is displayed with
+func (c CaughtPokemon) Name() string {
+	if c.Nickname != "" {
+		return c.Nickname
+	}
+	return c.Species.Name
+}
+
+// Stats returns the computed stats of the Pokemon at its current level
+func (c CaughtPokemon) Stats() battle.Stats {
+	return battle.CalculateIndividualStats(c.Species.Stats, c.Level, c.IVs, c.EVs, c.Nature)
+}
+
+// ExperienceToNextLevel returns the experience needed to reach the next
+// level, or 0 at the maximum level
+func (c CaughtPokemon) ExperienceToNextLevel() int {
+	next := c.GrowthRate.ExperienceForLevel(c.Level + 1)
+	if next == 0 {
+		return 0
+	}
+	return next - c.Experience
+}
+
+// GainExperience adds experience points and returns the number of levels
+// gained along the species' growth rate curve
+func (c *CaughtPokemon) GainExperience(experience int) int {
+	c.Experience += experience
+	if maxExperience := c.GrowthRate.ExperienceForLevel(100); maxExperience > 0 && c.Experience > maxExperience {
+		c.Experience = maxExperience
+	}
+
+	level := c.GrowthRate.LevelForExperience(c.Experience)
+	if level <= c.Level {
+		return 0
+	}
+
+	gained := level - c.Level
+	c.Level = level
+	return gained
+}
+
+// GainEffort adds the effort values yielded by defeating the given Pokemon,
+// respecting the per-stat and total limits
+func (c *CaughtPokemon) GainEffort(defeated pokemon.Pokemon) {
+	for _, stat := range defeated.Stats {
+		total := c.EVs.HP + c.EVs.Attack + c.EVs.Defense + c.EVs.SpecialAttack + c.EVs.SpecialDefense + c.EVs.Speed
+		gain := min(stat.Effort, maxEV-c.EVs.Get(stat.Stat.Name), maxTotalEV-total)
+		if gain > 0 {
+			c.EVs.Set(stat.Stat.Name, c.EVs.Get(stat.Stat.Name)+gain)
+		}
+	}
+}
+
+// matches reports whether the reference is the Pokemon's nickname, which
+// is case sensitive, or the name of its species in any case
+func (c CaughtPokemon) matches(ref string) bool {
+	return c.Nickname == ref || strings.EqualFold(c.Species.Name, ref)
+}
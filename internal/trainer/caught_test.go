@@ -0,0 +1,60 @@
+package trainer
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+func testGrowthRate() pokemon.GrowthRate {
+	// the "medium" growth rate, where the experience for a level is level^3
+	levels := make([]pokemon.GrowthRateExperienceLevel, 0, 100)
+	for level := 1; level <= 100; level++ {
+		experience := 0
+		if level > 1 {
+			experience = level * level * level
+		}
+		levels = append(levels, pokemon.GrowthRateExperienceLevel{Level: level, Experience: experience})
+	}
+	return pokemon.GrowthRate{Name: "medium", Levels: levels}
+}
+
+func TestNewCaughtPokemon(t *testing.T) {
+	caught := NewCaughtPokemon(pokemon.Pokemon{Name: "pidgey"}, 5, pokemon.Nature{}, testGrowthRate(), rand.New(rand.NewSource(1)))
+
+	if caught.Experience != 125 {
+		t.Errorf("expected 125 experience at level 5, got %d", caught.Experience)
+	}
+	if caught.IVs.HP < 0 || caught.IVs.HP > maxIV {
+		t.Errorf("expected IVs between 0 and %d, got %d", maxIV, caught.IVs.HP)
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	caught := NewCaughtPokemon(pokemon.Pokemon{Name: "pidgey"}, 5, pokemon.Nature{}, testGrowthRate(), rand.New(rand.NewSource(1)))
+
+	if gained := caught.GainExperience(50); gained != 0 {
+		t.Errorf("expected no level gained, got %d", gained)
+	}
+	if caught.ExperienceToNextLevel() != 41 {
+		t.Errorf("expected 41 experience to the next level, got %d", caught.ExperienceToNextLevel())
+	}
+	if gained := caught.GainExperience(300); gained != 2 {
+		t.Errorf("expected 2 levels gained, got %d", gained)
+	}
+	if caught.Level != 7 {
+		t.Errorf("expected level 7, got %d", caught.Level)
+	}
+}
+
+func TestGainEffortRespectsLimits(t *testing.T) {
+	caught := CaughtPokemon{}
+	caught.EVs.Speed = 251
+	defeated := pokemon.Pokemon{Stats: []pokemon.Stats{{Effort: 3, Stat: pokemon.Stat{Name: "speed"}}}}
+
+	caught.GainEffort(defeated)
+	if caught.EVs.Speed != maxEV {
+		t.Errorf("expected speed EVs to be capped at %d, got %d", maxEV, caught.EVs.Speed)
+	}
+}
@@ -0,0 +1,49 @@
+package trainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Load reads a trainer written by Save.  A missing file gives a new
+// trainer.
+func Load(path string) (*Trainer, error) {
+	t := New()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return t, err
+	}
+
+	if err := json.Unmarshal(data, t); err != nil {
+		return New(), fmt.Errorf("reading %s: %w", path, err)
+	}
+	for _, caught := range t.All() {
+		t.nextID = max(t.nextID, caught.ID+1)
+	}
+	return t, nil
+}
+
+// Save writes the trainer to the file, creating its directory if needed.
+// The file is replaced as a whole so an interrupted save does not lose the
+// previous one.
+func (t *Trainer) Save(path string) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
@@ -0,0 +1,36 @@
+package trainer
+
+import "time"
+
+// SeenPokemon records where and when a species was first seen
+type SeenPokemon struct {
+	Name   string
+	SeenAt string
+	SeenOn time.Time
+}
+
+// See records that the species was seen at the location area, which may be
+// empty, and reports whether it was seen for the first time
+func (t *Trainer) See(name string, locationArea string, when time.Time) bool {
+	if t.HasSeen(name) {
+		return false
+	}
+	t.Seen = append(t.Seen, SeenPokemon{Name: name, SeenAt: locationArea, SeenOn: when})
+	return true
+}
+
+// HasSeen reports whether the trainer has seen the species
+func (t *Trainer) HasSeen(name string) bool {
+	_, found := t.FindSeen(name)
+	return found
+}
+
+// FindSeen returns when and where the species was first seen
+func (t *Trainer) FindSeen(name string) (SeenPokemon, bool) {
+	for _, seen := range t.Seen {
+		if seen.Name == name {
+			return seen, true
+		}
+	}
+	return SeenPokemon{}, false
+}
@@ -0,0 +1,229 @@
+package trainer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PartySize is the maximum number of Pokemon in a trainer's party
+const PartySize = 6
+
+// BoxSize is the maximum number of Pokemon in a single storage box
+const BoxSize = 30
+
+// Box is a storage box for Pokemon that are not in the party
+type Box struct {
+	Pokemon []CaughtPokemon
+}
+
+// Trainer contains the party and storage boxes of the trainer, the species
+// seen so far in the order they were first seen, and the location area the
+// trainer is in, empty until they start their journey
+type Trainer struct {
+	Party    []CaughtPokemon
+	Boxes    []Box
+	Seen     []SeenPokemon
+	Location string
+	nextID   int
+}
+
+// New creates a new trainer without any Pokemon
+func New() *Trainer {
+	return &Trainer{
+		Party:  make([]CaughtPokemon, 0, PartySize),
+		Boxes:  []Box{},
+		Seen:   []SeenPokemon{},
+		nextID: 1,
+	}
+}
+
+// Catch assigns a unique ID to a newly caught Pokemon and adds it to the
+// party, or to the first box with room if the party is full.  It returns
+// the caught Pokemon and whether it was sent to a box.  Caught species are
+// also seen.
+func (t *Trainer) Catch(caught CaughtPokemon) (CaughtPokemon, bool) {
+	caught.ID = t.nextID
+	t.nextID++
+	t.See(caught.Species.Name, caught.CaughtAt, caught.CaughtOn)
+
+	if len(t.Party) < PartySize {
+		t.Party = append(t.Party, caught)
+		return caught, false
+	}
+
+	t.addToBox(caught)
+	return caught, true
+}
+
+// All returns every caught Pokemon, party first and then boxes in order
+func (t *Trainer) All() []CaughtPokemon {
+	all := make([]CaughtPokemon, 0, len(t.Party))
+	all = append(all, t.Party...)
+	for _, box := range t.Boxes {
+		all = append(all, box.Pokemon...)
+	}
+	return all
+}
+
+// HasCaught reports whether the trainer has caught the given species
+func (t *Trainer) HasCaught(speciesName string) bool {
+	for _, caught := range t.All() {
+		if caught.Species.Name == speciesName {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the caught Pokemon referred to by an ID (optionally prefixed
+// with '#'), by nickname or by species name, searching the party before the boxes
+func (t *Trainer) Find(ref string) (*CaughtPokemon, error) {
+	if id, isID := ParseID(ref); isID {
+		if caught := t.findByID(id); caught != nil {
+			return caught, nil
+		}
+		return nil, fmt.Errorf("you do not have a pokemon with ID %d", id)
+	}
+
+	for i := range t.Party {
+		if t.Party[i].matches(ref) {
+			return &t.Party[i], nil
+		}
+	}
+	for b := range t.Boxes {
+		for i := range t.Boxes[b].Pokemon {
+			if t.Boxes[b].Pokemon[i].matches(ref) {
+				return &t.Boxes[b].Pokemon[i], nil
+			}
+		}
+	}
+
+	return nil, errors.New("you have not caught that pokemon")
+}
+
+// Deposit moves a party member into the first box with room.  The last
+// Pokemon in the party cannot be deposited.
+func (t *Trainer) Deposit(ref string) (CaughtPokemon, error) {
+	caught, err := t.Find(ref)
+	if err != nil {
+		return CaughtPokemon{}, err
+	}
+
+	slot := t.partySlot(caught.ID)
+	if slot == -1 {
+		return CaughtPokemon{}, fmt.Errorf("%s is not in your party", caught.Name())
+	}
+	if len(t.Party) == 1 {
+		return CaughtPokemon{}, errors.New("you cannot deposit your last party pokemon")
+	}
+
+	deposited := t.Party[slot]
+	t.Party = append(t.Party[:slot], t.Party[slot+1:]...)
+	t.addToBox(deposited)
+
+	return deposited, nil
+}
+
+// Withdraw moves a boxed Pokemon into the party if the party has room
+func (t *Trainer) Withdraw(ref string) (CaughtPokemon, error) {
+	caught, err := t.Find(ref)
+	if err != nil {
+		return CaughtPokemon{}, err
+	}
+
+	box, slot := t.boxSlot(caught.ID)
+	if box == -1 {
+		return CaughtPokemon{}, fmt.Errorf("%s is already in your party", caught.Name())
+	}
+	if len(t.Party) >= PartySize {
+		return CaughtPokemon{}, errors.New("your party is full")
+	}
+
+	withdrawn := t.removeFromBox(box, slot)
+	t.Party = append(t.Party, withdrawn)
+
+	return withdrawn, nil
+}
+
+// Swap exchanges the places of two caught Pokemon.  Two party members trade
+// party slots, while a party member and a boxed Pokemon trade between the
+// party and the box.
+func (t *Trainer) Swap(firstRef, secondRef string) error {
+	first, err := t.Find(firstRef)
+	if err != nil {
+		return err
+	}
+	second, err := t.Find(secondRef)
+	if err != nil {
+		return err
+	}
+	if first.ID == second.ID {
+		return errors.New("cannot swap a pokemon with itself")
+	}
+
+	if t.partySlot(first.ID) == -1 && t.partySlot(second.ID) == -1 {
+		return errors.New("at least one of the pokemon must be in your party")
+	}
+
+	*first, *second = *second, *first
+	return nil
+}
+
+func (t *Trainer) findByID(id int) *CaughtPokemon {
+	if slot := t.partySlot(id); slot != -1 {
+		return &t.Party[slot]
+	}
+	if box, slot := t.boxSlot(id); box != -1 {
+		return &t.Boxes[box].Pokemon[slot]
+	}
+	return nil
+}
+
+func (t *Trainer) partySlot(id int) int {
+	for i, caught := range t.Party {
+		if caught.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *Trainer) boxSlot(id int) (int, int) {
+	for b, box := range t.Boxes {
+		for i, caught := range box.Pokemon {
+			if caught.ID == id {
+				return b, i
+			}
+		}
+	}
+	return -1, -1
+}
+
+func (t *Trainer) addToBox(caught CaughtPokemon) {
+	for i := range t.Boxes {
+		if len(t.Boxes[i].Pokemon) < BoxSize {
+			t.Boxes[i].Pokemon = append(t.Boxes[i].Pokemon, caught)
+			return
+		}
+	}
+
+	t.Boxes = append(t.Boxes, Box{Pokemon: []CaughtPokemon{caught}})
+}
+
+func (t *Trainer) removeFromBox(box, slot int) CaughtPokemon {
+	removed := t.Boxes[box].Pokemon[slot]
+	t.Boxes[box].Pokemon = append(t.Boxes[box].Pokemon[:slot], t.Boxes[box].Pokemon[slot+1:]...)
+	return removed
+}
+
+// ParseID returns the ID a reference to a caught Pokemon is, such as 3 or
+// #3, and false if it is a name
+func ParseID(ref string) (int, bool) {
+	id, err := strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,165 @@
+package trainer
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+func catchAll(t *Trainer, names ...string) {
+	for _, name := range names {
+		t.Catch(CaughtPokemon{Species: pokemon.Pokemon{Name: name}})
+	}
+}
+
+func TestCatchAssignsUniqueIDs(t *testing.T) {
+	tr := New()
+	catchAll(tr, "pidgey", "pidgey")
+
+	if len(tr.Party) != 2 {
+		t.Fatalf("expected 2 pokemon in party, got %d", len(tr.Party))
+	}
+	if tr.Party[0].ID == tr.Party[1].ID {
+		t.Errorf("expected unique IDs, got %d twice", tr.Party[0].ID)
+	}
+}
+
+func TestCatchSendsToBoxWhenPartyIsFull(t *testing.T) {
+	tr := New()
+	for i := 0; i < PartySize; i++ {
+		catchAll(tr, fmt.Sprintf("pokemon-%d", i))
+	}
+
+	caught, boxed := tr.Catch(CaughtPokemon{Species: pokemon.Pokemon{Name: "pidgey"}})
+	if !boxed {
+		t.Errorf("expected pidgey to be sent to a box")
+	}
+	if len(tr.Boxes) != 1 || tr.Boxes[0].Pokemon[0].ID != caught.ID {
+		t.Errorf("expected pidgey in the first box")
+	}
+}
+
+func TestFind(t *testing.T) {
+	tr := New()
+	catchAll(tr, "pidgey", "rattata")
+
+	cases := []struct {
+		ref      string
+		expected string
+	}{
+		{ref: "1", expected: "pidgey"},
+		{ref: "#2", expected: "rattata"},
+		{ref: "rattata", expected: "rattata"},
+		{ref: "Rattata", expected: "rattata"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			caught, err := tr.Find(c.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if caught.Name() != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, caught.Name())
+			}
+		})
+	}
+
+	if _, err := tr.Find("mew"); err == nil {
+		t.Errorf("expected an error for a pokemon that was not caught")
+	}
+}
+
+func TestDepositWithdrawSwap(t *testing.T) {
+	tr := New()
+	catchAll(tr, "pidgey", "rattata", "zubat")
+
+	if _, err := tr.Deposit("rattata"); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.Party) != 2 || len(tr.Boxes[0].Pokemon) != 1 {
+		t.Fatalf("expected rattata to be in a box")
+	}
+
+	if err := tr.Swap("pidgey", "rattata"); err != nil {
+		t.Fatal(err)
+	}
+	if tr.Party[0].Name() != "rattata" || tr.Boxes[0].Pokemon[0].Name() != "pidgey" {
+		t.Fatalf("expected rattata and pidgey to trade places")
+	}
+
+	if _, err := tr.Withdraw("pidgey"); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.Party) != 3 || len(tr.Boxes[0].Pokemon) != 0 {
+		t.Errorf("expected pidgey to be back in the party")
+	}
+
+	if _, err := tr.Withdraw("pidgey"); err == nil {
+		t.Errorf("expected an error withdrawing a party pokemon")
+	}
+}
+
+func TestCannotDepositLastPartyPokemon(t *testing.T) {
+	tr := New()
+	catchAll(tr, "pidgey")
+
+	if _, err := tr.Deposit("pidgey"); err == nil {
+		t.Errorf("expected an error depositing the last party pokemon")
+	}
+}
+
+func TestSee(t *testing.T) {
+	tr := New()
+	first := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	if !tr.See("tentacool", "canalave-city-area", first) {
+		t.Errorf("expected tentacool to be seen for the first time")
+	}
+	if tr.See("tentacool", "eterna-city-area", first.Add(time.Hour)) {
+		t.Errorf("expected tentacool to have been seen before")
+	}
+	seen, found := tr.FindSeen("tentacool")
+	if !found || seen.SeenAt != "canalave-city-area" || !seen.SeenOn.Equal(first) {
+		t.Errorf("expected the first sighting to be kept, got %+v", seen)
+	}
+
+	catchAll(tr, "pidgey")
+	if !tr.HasSeen("pidgey") {
+		t.Errorf("expected caught pidgey to be seen")
+	}
+	if tr.HasCaught("tentacool") || len(tr.Seen) != 2 {
+		t.Errorf("expected tentacool seen but not caught, got %+v", tr.Seen)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trainer.json")
+
+	tr := New()
+	catchAll(tr, "pidgey", "rattata")
+	tr.See("zubat", "mt-moon-1f", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	tr.Location = "kanto-route-1-area"
+	if err := tr.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Party) != 2 || loaded.Location != tr.Location || !loaded.HasSeen("zubat") {
+		t.Errorf("expected the saved trainer, got %+v", loaded)
+	}
+	caught, _ := loaded.Catch(CaughtPokemon{Species: pokemon.Pokemon{Name: "spearow"}})
+	if caught.ID != 3 {
+		t.Errorf("expected IDs to continue after the saved Pokemon, got %d", caught.ID)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Errorf("expected a missing file to give a new trainer, got %v", err)
+	}
+}
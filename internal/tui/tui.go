@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/render"
+)
+
+// List is a scrollable list of items with one selected item
+type List struct {
+	Items    []string
+	Selected int
+	offset   int
+}
+
+// SetItems replaces the items of the list and selects the first one
+func (l *List) SetItems(items []string) {
+	l.Items = items
+	l.Selected = 0
+	l.offset = 0
+}
+
+// Move moves the selection by delta items, stopping at either end
+func (l *List) Move(delta int) {
+	if len(l.Items) == 0 {
+		return
+	}
+	l.Selected = max(0, min(l.Selected+delta, len(l.Items)-1))
+}
+
+// Current returns the selected item, or an empty string if the list is
+// empty
+func (l *List) Current() string {
+	if len(l.Items) == 0 {
+		return ""
+	}
+	return l.Items[l.Selected]
+}
+
+// Lines returns the items that fit in the given height, scrolled so the
+// selected item is visible, with the selected item highlighted
+func (l *List) Lines(height int, highlight func(string) string) []string {
+	if height <= 0 {
+		return nil
+	}
+	if l.Selected < l.offset {
+		l.offset = l.Selected
+	}
+	if l.Selected >= l.offset+height {
+		l.offset = l.Selected - height + 1
+	}
+
+	end := min(l.offset+height, len(l.Items))
+	lines := make([]string, 0, end-l.offset)
+	for i := l.offset; i < end; i++ {
+		if i == l.Selected {
+			lines = append(lines, highlight("> "+l.Items[i]))
+		} else {
+			lines = append(lines, "  "+l.Items[i])
+		}
+	}
+	return lines
+}
+
+// Pane is a bordered, titled box of lines
+type Pane struct {
+	Title   string
+	Lines   []string
+	Focused bool
+
+	// Weight is the share of the screen width the pane takes up relative
+	// to the other panes
+	Weight int
+}
+
+// InnerSize returns the number of characters and lines that fit inside
+// each pane's border when the panes are laid out on a screen of the given
+// size
+func InnerSize(panes []Pane, width, height int) ([]int, int) {
+	widths := paneWidths(panes, width)
+	for i := range widths {
+		widths[i] = max(widths[i]-2, 0)
+	}
+	return widths, max(height-3, 0)
+}
+
+// Frame lays the panes out side by side above a status line and returns
+// the lines of the screen.  The border of the focused pane is bold.
+func Frame(r *render.Renderer, panes []Pane, width, height int, status string) []string {
+	widths := paneWidths(panes, width)
+	innerWidths, innerHeight := InnerSize(panes, width, height)
+
+	lines := make([]string, 0, height)
+	top := make([]string, len(panes))
+	bottom := make([]string, len(panes))
+	for i, pane := range panes {
+		if widths[i] < 3 {
+			continue
+		}
+		title := render.Truncate(" "+pane.Title+" ", max(innerWidths[i]-1, 0))
+		top[i] = border(r, pane, "┌─"+title+strings.Repeat("─", max(innerWidths[i]-1-render.VisibleWidth(title), 0))+"┐")
+		bottom[i] = border(r, pane, "└"+strings.Repeat("─", innerWidths[i])+"┘")
+	}
+	lines = append(lines, strings.Join(top, ""))
+
+	for row := 0; row < innerHeight; row++ {
+		var line strings.Builder
+		for i, pane := range panes {
+			if widths[i] < 3 {
+				continue
+			}
+			text := ""
+			if row < len(pane.Lines) {
+				text = render.Truncate(pane.Lines[row], innerWidths[i])
+			}
+			line.WriteString(border(r, pane, "│"))
+			line.WriteString(text + strings.Repeat(" ", innerWidths[i]-render.VisibleWidth(text)))
+			line.WriteString(border(r, pane, "│"))
+		}
+		lines = append(lines, line.String())
+	}
+
+	lines = append(lines, strings.Join(bottom, ""))
+	return append(lines, render.Truncate(status, width))
+}
+
+func border(r *render.Renderer, pane Pane, text string) string {
+	if pane.Focused {
+		return r.Bold(text)
+	}
+	return text
+}
+
+// paneWidths divides the width between the panes by weight, giving any
+// remainder to the last pane
+func paneWidths(panes []Pane, width int) []int {
+	total := 0
+	for _, pane := range panes {
+		total += max(pane.Weight, 1)
+	}
+
+	widths := make([]int, len(panes))
+	remaining := width
+	for i, pane := range panes {
+		if i == len(panes)-1 {
+			widths[i] = remaining
+			break
+		}
+		widths[i] = width * max(pane.Weight, 1) / total
+		remaining -= widths[i]
+	}
+	return widths
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rkanagy/pokedexcli/internal/render"
+)
+
+func TestListLines(t *testing.T) {
+	highlight := func(line string) string { return "[" + line + "]" }
+
+	cases := []struct {
+		moves    []int
+		height   int
+		expected []string
+	}{
+		{
+			moves:    nil,
+			height:   2,
+			expected: []string{"[> a]", "  b"},
+		},
+		{
+			moves:    []int{3},
+			height:   2,
+			expected: []string{"  c", "[> d]"},
+		},
+		{
+			moves:    []int{3, -2},
+			height:   2,
+			expected: []string{"[> b]", "  c"},
+		},
+		{
+			moves:    []int{10},
+			height:   5,
+			expected: []string{"  a", "  b", "  c", "[> d]"},
+		},
+		{
+			moves:    []int{-1},
+			height:   0,
+			expected: nil,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			list := List{}
+			list.SetItems([]string{"a", "b", "c", "d"})
+			for _, delta := range c.moves {
+				list.Move(delta)
+				list.Lines(c.height, highlight)
+			}
+			actual := list.Lines(c.height, highlight)
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %q, actual %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFrame(t *testing.T) {
+	panes := []Pane{
+		{Title: "Areas", Lines: []string{"> canalave-city-area", "  eterna-city-area"}, Weight: 1},
+		{Title: "Pokemon", Lines: []string{"tentacool"}, Weight: 1, Focused: true},
+	}
+
+	expected := []string{
+		"┌─ Areas ──┐┌─ Pokemon ┐",
+		"│> canalave││tentacool │",
+		"│  eterna-c││          │",
+		"│          ││          │",
+		"└──────────┘└──────────┘",
+		"q: quit",
+	}
+
+	actual := Frame(&render.Renderer{}, panes, 24, 6, "q: quit")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected:\n%q\nactual:\n%q", expected, actual)
+	}
+}
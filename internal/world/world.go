@@ -0,0 +1,142 @@
+package world
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// maps contains the bundled adjacency files, one per region, listing the
+// pairs of locations that are connected
+//
+//go:embed maps/*.json
+var maps embed.FS
+
+// Source is where the world is read from, usually a pokemon.API
+type Source interface {
+	GetLocationArea(name string) (pokemon.LocationArea, error)
+	GetLocation(name string) (pokemon.Location, error)
+	GetRegion(name string) (pokemon.Region, error)
+}
+
+// Neighbors returns the location areas that can be travelled to from the
+// location area, sorted by name.  Areas of the same location are always
+// neighbors.  Locations are connected as listed in the bundled map of
+// their region, or for regions without one, to the locations next to them
+// in the region's list.  A location without areas, such as most towns, is
+// passed through to the areas of the locations next to it, but no
+// further, so the trainer never skips past a place they could stop at.
+func Neighbors(source Source, area string) ([]string, error) {
+	locationArea, err := source.GetLocationArea(area)
+	if err != nil {
+		return nil, err
+	}
+	start := locationArea.Location.Name
+	location, err := source.GetLocation(start)
+	if err != nil {
+		return nil, err
+	}
+
+	neighbors := make(map[string]bool)
+	for _, other := range location.Areas {
+		neighbors[other.Name] = true
+	}
+
+	if location.Region.Name != "" {
+		graph, err := regionGraph(source, location.Region.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range graph[start] {
+			next, err := source.GetLocation(name)
+			if err != nil {
+				return nil, err
+			}
+			for _, other := range next.Areas {
+				neighbors[other.Name] = true
+			}
+			if len(next.Areas) > 0 {
+				continue
+			}
+
+			for _, beyondName := range graph[name] {
+				if beyondName == start {
+					continue
+				}
+				beyond, err := source.GetLocation(beyondName)
+				if err != nil {
+					return nil, err
+				}
+				for _, other := range beyond.Areas {
+					neighbors[other.Name] = true
+				}
+			}
+		}
+	}
+
+	delete(neighbors, area)
+	names := make([]string, 0, len(neighbors))
+	for name := range neighbors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// regionGraph returns the locations connected to each location of the
+// region
+func regionGraph(source Source, name string) (map[string][]string, error) {
+	region, err := source.GetRegion(name)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(region.Locations))
+	for _, location := range region.Locations {
+		known[location.Name] = true
+	}
+
+	edges, err := bundledEdges(name)
+	if err != nil {
+		return nil, err
+	}
+	if edges == nil {
+		for i := 1; i < len(region.Locations); i++ {
+			edges = append(edges, [2]string{region.Locations[i-1].Name, region.Locations[i].Name})
+		}
+	}
+
+	graph := make(map[string][]string)
+	for _, edge := range edges {
+		// the bundled maps may name locations the API does not have
+		if !known[edge[0]] || !known[edge[1]] {
+			continue
+		}
+		graph[edge[0]] = append(graph[edge[0]], edge[1])
+		graph[edge[1]] = append(graph[edge[1]], edge[0])
+	}
+	return graph, nil
+}
+
+// bundledEdges returns the connected locations of the region's bundled
+// map, or nil when there is none
+func bundledEdges(region string) ([][2]string, error) {
+	data, err := maps.ReadFile("maps/" + region + ".json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	edges := [][2]string{}
+	if err := json.Unmarshal(data, &edges); err != nil {
+		return nil, fmt.Errorf("reading the map of %s: %w", region, err)
+	}
+	return edges, nil
+}
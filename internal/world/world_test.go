@@ -0,0 +1,111 @@
+package world
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// testSource is a world of regions, each a list of locations with their
+// areas
+type testSource map[string][][]string
+
+func (s testSource) GetLocationArea(name string) (pokemon.LocationArea, error) {
+	for _, locations := range s {
+		for _, location := range locations {
+			for _, area := range location[1:] {
+				if area == name {
+					return pokemon.LocationArea{Name: name, Location: pokemon.LocationNR{NamedAPIResource: pokemon.NamedAPIResource{Name: location[0]}}}, nil
+				}
+			}
+		}
+	}
+	return pokemon.LocationArea{}, fmt.Errorf("no location area %s", name)
+}
+
+func (s testSource) GetLocation(name string) (pokemon.Location, error) {
+	for region, locations := range s {
+		for _, location := range locations {
+			if location[0] != name {
+				continue
+			}
+			result := pokemon.Location{Name: name, Region: pokemon.RegionNR{NamedAPIResource: pokemon.NamedAPIResource{Name: region}}}
+			for _, area := range location[1:] {
+				result.Areas = append(result.Areas, pokemon.LocationAreaNR{NamedAPIResource: pokemon.NamedAPIResource{Name: area}})
+			}
+			return result, nil
+		}
+	}
+	return pokemon.Location{}, fmt.Errorf("no location %s", name)
+}
+
+func (s testSource) GetRegion(name string) (pokemon.Region, error) {
+	region := pokemon.Region{Name: name}
+	for _, location := range s[name] {
+		region.Locations = append(region.Locations, pokemon.LocationNR{NamedAPIResource: pokemon.NamedAPIResource{Name: location[0]}})
+	}
+	return region, nil
+}
+
+var testWorld = testSource{
+	"kanto": {
+		{"pallet-town"},
+		{"viridian-city", "viridian-city-area"},
+		{"kanto-route-1", "kanto-route-1-area"},
+		{"kanto-sea-route-21", "kanto-sea-route-21-area"},
+		{"kanto-route-2", "kanto-route-2-south", "kanto-route-2-north"},
+	},
+	"hoenn": {
+		{"littleroot-town"},
+		{"hoenn-route-101", "hoenn-route-101-area"},
+		{"oldale-town", "oldale-town-area"},
+		{"hoenn-route-103", "hoenn-route-103-area"},
+	},
+	"johto": {
+		{"new-bark-town", "new-bark-town-area"},
+		{"johto-route-29"},
+		{"cherrygrove-city"},
+		{"johto-route-30", "johto-route-30-area"},
+	},
+}
+
+func TestNeighbors(t *testing.T) {
+	cases := []struct {
+		area     string
+		expected []string
+	}{
+		{area: "kanto-route-1-area", expected: []string{"kanto-sea-route-21-area", "viridian-city-area"}},
+		{area: "viridian-city-area", expected: []string{"kanto-route-1-area", "kanto-route-2-north", "kanto-route-2-south"}},
+		{area: "kanto-route-2-north", expected: []string{"kanto-route-2-south", "viridian-city-area"}},
+		{area: "hoenn-route-101-area", expected: []string{"oldale-town-area"}},
+		{area: "oldale-town-area", expected: []string{"hoenn-route-101-area", "hoenn-route-103-area"}},
+		{area: "new-bark-town-area", expected: []string{}},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, err := Neighbors(testWorld, c.area)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("%s: expected %v, actual %v", c.area, c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBundledMaps(t *testing.T) {
+	entries, err := maps.ReadDir("maps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		region := entry.Name()[:len(entry.Name())-len(".json")]
+		if _, err := bundledEdges(region); err != nil {
+			t.Errorf("%s: %v", entry.Name(), err)
+		}
+	}
+}
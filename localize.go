@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// language is the language Pokemon and location area names are displayed in
+var language = pokemon.English
+
+// localNames maps the normalized Pokemon and location area names of each
+// language back to the names the API uses, so they can be typed in as
+// well.  The names of a language are fetched the first time a name that
+// is not an API name is looked up.
+var localNames = make(map[string]map[string]string)
+
+// displayNames caches the names of Pokemon and location areas in each
+// language by their API names
+var displayNames = make(map[string]map[string]string)
+
+// pokemonName returns the name of the Pokemon in the current language,
+// which is the name of its species.  The API names are displayed in
+// English, and when the names cannot be fetched.
+func pokemonName(name string) string {
+	if language == pokemon.English {
+		return name
+	}
+	if local, found := displayNames[language][name]; found {
+		return local
+	}
+	details, err := pokemonAPI.GetPokemon(name)
+	if err != nil {
+		return name
+	}
+	species, err := pokemonAPI.GetPokemonSpecies(details.Species.Name)
+	if err != nil {
+		return name
+	}
+	return localName(species.Names, name)
+}
+
+// fetchPokemonNames fetches the names in the current language of the
+// Pokemon not displayed before all at once, so a list of them is not
+// fetched one Pokemon after another by pokemonName
+func fetchPokemonNames(names []string) {
+	if language == pokemon.English {
+		return
+	}
+	missing := []string{}
+	for _, name := range names {
+		if _, found := displayNames[language][name]; !found {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	// the names of those that fail are left to pokemonName
+	details, _ := pokemonAPI.GetPokemonBatch(missing)
+	speciesNames := make([]string, 0, len(details))
+	for _, d := range details {
+		speciesNames = append(speciesNames, d.Species.Name)
+	}
+	species, _ := pokemonAPI.GetPokemonSpeciesBatch(speciesNames)
+	for i, s := range species {
+		if details[i].Name != "" && s.Name != "" {
+			localName(s.Names, details[i].Name)
+		}
+	}
+}
+
+// fetchLocationAreaNames fetches the names in the current language of the
+// location areas not displayed before all at once, like fetchPokemonNames
+func fetchLocationAreaNames(names []string) {
+	if language == pokemon.English {
+		return
+	}
+	missing := []string{}
+	for _, name := range names {
+		if _, found := displayNames[language][name]; !found {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	areas, _ := pokemonAPI.GetLocationAreaBatch(missing)
+	for _, area := range areas {
+		if area.Name != "" {
+			localName(area.Names, area.Name)
+		}
+	}
+}
+
+// locationAreaName returns the name of the location area in the current
+// language, like pokemonName
+func locationAreaName(name string) string {
+	if language == pokemon.English {
+		return name
+	}
+	if local, found := displayNames[language][name]; found {
+		return local
+	}
+	area, err := pokemonAPI.GetLocationArea(name)
+	if err != nil {
+		return name
+	}
+	return localName(area.Names, name)
+}
+
+// localName returns the name in the current language, or the API name if
+// there is none, remembering it for displaying the API name again
+func localName(names []pokemon.Name, apiName string) string {
+	local, found := pokemon.LocalName(names, language)
+	if !found {
+		local = apiName
+	}
+	rememberDisplayName(language, apiName, local)
+	return local
+}
+
+func rememberDisplayName(lang string, apiName string, local string) {
+	if displayNames[lang] == nil {
+		displayNames[lang] = make(map[string]string)
+	}
+	displayNames[lang][apiName] = local
+}
+
+// fromLocalName returns the API name of a name in the current language, or
+// the name itself if it is not one.  Callers try the name as an API name
+// first, since the names of a language are only fetched when needed.
+func fromLocalName(name string) string {
+	if language == pokemon.English {
+		return name
+	}
+	if apiName, found := localNameIndex(language)[normalizeName(name)]; found {
+		return apiName
+	}
+	return name
+}
+
+// localNameIndex returns the API names of the Pokemon and location areas by
+// their normalized names in the language, fetching them with a progress
+// bar the first time.  Names that cannot be fetched are reported once and
+// left out.  If the user stops the fetch with Ctrl-C the names fetched are
+// used but not kept, so they are fetched again next time.
+func localNameIndex(lang string) map[string]string {
+	if names, found := localNames[lang]; found {
+		return names
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Fprintf(os.Stderr, "Fetching the names in %s, which is only done once...\n", lang)
+	fetched, err := pokemonAPI.GetLocalNames(ctx, lang, func(stage string, done, total int) {
+		showProgress(os.Stderr, stage, done, total)
+	})
+	names := make(map[string]string, len(fetched))
+	for local, apiName := range fetched {
+		names[normalizeName(local)] = apiName
+		rememberDisplayName(lang, apiName, local)
+	}
+	if errors.Is(err, context.Canceled) {
+		if renderer.Terminal {
+			fmt.Fprintln(os.Stderr)
+		}
+		return names
+	}
+	if err != nil {
+		// there can be an error for each of thousands of names
+		first, _, _ := strings.Cut(err.Error(), "\n")
+		errorHandler(fmt.Errorf("Some names in %s could not be fetched: %s", lang, first))
+	}
+
+	localNames[lang] = names
+	return names
+}
+
+func commandLanguage(args cli.Args) error {
+	if !args.IsSet("code") {
+		value, source, err := settings.Get(languageSetting)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s (from %s)\n", value, source)
+		return nil
+	}
+
+	if err := settings.Set(languageSetting, args.String("code")); err != nil {
+		return err
+	}
+	reportSettingChange(languageSetting)
+	return nil
+}
+
+// completeLanguage completes the language codes
+func completeLanguage(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	return pokemon.Languages
+}
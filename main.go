@@ -1,64 +1,197 @@
 package main
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
+	"time"
 
+	"github.com/rkanagy/pokedexcli/internal/battle"
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/fuzzy"
+	"github.com/rkanagy/pokedexcli/internal/lineedit"
+	"github.com/rkanagy/pokedexcli/internal/output"
 	"github.com/rkanagy/pokedexcli/internal/pokemon"
+	"github.com/rkanagy/pokedexcli/internal/render"
+	"github.com/rkanagy/pokedexcli/internal/trainer"
 )
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(parameters ...string) error
+	usage       string
+	aliases     []string
+	examples    []string
+	spec        cli.Spec
+	callback    func(args cli.Args) error
+	complete    func(argIndex int) []string
 }
 
-type pokedexType map[string]pokemon.Pokemon
-
-var pokemonAPI pokemon.API = pokemon.NewAPI()
-var pokedex pokedexType = make(pokedexType, 10)
+var pokemonAPI pokemon.API
+var currentTrainer *trainer.Trainer = trainer.New()
+var lineEditor *lineedit.Editor = lineedit.New(os.Stdin, os.Stdout)
+var renderer *render.Renderer = render.New(os.Stdout)
+
+// the range of widths of the base stat bars shown by inspect
+const minStatBarWidth = 8
+const maxStatBarWidth = 40
+
+// programSpec describes the flags and arguments of the pokedexcli program
+// itself; any arguments are run as a single command
+var programSpec = cli.Spec{
+	Flags: []cli.Flag{
+		{Name: "script", CaseSensitive: true, Usage: "run the commands in the given file"},
+		{Name: "fail-fast", Kind: cli.Bool, Usage: "stop with a non-zero exit code at the first failing command"},
+		{Name: outputSetting, Short: "o", Choices: output.Formats, Usage: "the format commands with listings, such as map, pokedex and where, write them in (default text)"},
+		{Name: cacheIntervalSetting, CaseSensitive: true, Usage: "how long API responses are cached (default 5m0s)"},
+		{Name: pageSizeSetting, Kind: cli.Int, Usage: "the number of location areas map and mapb list at a time (default 20)"},
+		{Name: workersSetting, Kind: cli.Int, Usage: "the number of API requests made at once by commands that need many (default 8)"},
+		{Name: rateLimitSetting, Kind: cli.Int, Usage: "the most API requests started each second (default 0, no limit)"},
+		{Name: "prefetch", Choices: prefetchDepths, Usage: "fill the cache before running, as the prefetch command does"},
+		{Name: baseURLSetting, CaseSensitive: true, Usage: "the address of the PokeAPI server"},
+		{Name: languageSetting, Choices: pokemon.Languages, CaseSensitive: true, Usage: "the language Pokemon and location area names are displayed in (default en)"},
+	},
+	Args: []cli.Arg{
+		{Name: "command", Variadic: true, CaseSensitive: true, Usage: "a single command to run"},
+	},
+	FlagsFirst: true,
+}
 
 func main() {
+	options, err := programSpec.Parse(os.Args[1:])
+	if err != nil {
+		errorHandler(fmt.Errorf("%v\nusage: %s", err, programSpec.Usage("pokedexcli")))
+		os.Exit(2)
+	}
+
+	// settings come from the flags, then the POKEDEX_* environment
+	// variables, then the config file
+	if err := settings.Load(); err != nil {
+		errorHandler(err)
+	}
+	for _, name := range []string{outputSetting, cacheIntervalSetting, pageSizeSetting, workersSetting, rateLimitSetting, baseURLSetting, languageSetting} {
+		if !options.IsSet(name) {
+			continue
+		}
+		if err := settings.SetFlag(name, options.String(name)); err != nil {
+			errorHandler(err)
+			os.Exit(2)
+		}
+	}
+	applySettings()
+
+	userCommands, err = loadUserCommands(userCommandsFilePath())
+	if err != nil {
+		errorHandler(err)
+	}
+	loadTrainer(trainerFilePath())
+	if options.IsSet("prefetch") {
+		if err := prefetch(options.String("prefetch")); err != nil {
+			errorHandler(err)
+		}
+	}
+
 	commands := initializeCliCommands()
+	switch {
+	case options.IsSet("command"):
+		os.Exit(runOneShot(commands, options.List("command")))
+	case options.IsSet("script"):
+		os.Exit(runScriptFile(commands, options.String("script"), options.Bool("fail-fast")))
+	case !lineEditor.IsTerminal():
+		lineEditor = lineedit.NewReader(os.Stdin, os.Stdout)
+		os.Exit(runScript(commands, options.Bool("fail-fast")))
+	default:
+		runREPL(commands)
+	}
+}
+
+func runREPL(commands map[string]cliCommand) {
+	lineEditor.Completer = newCompleter(commands)
+	err := lineEditor.History.Load(historyFilePath())
+	if err != nil {
+		errorHandler(err)
+	}
 
 	// The Read-Eval-Print loop for the CLI
-	reader := bufio.NewScanner(os.Stdin)
-	fmt.Print("Pokedex > ")
-
-	for reader.Scan() {
-		text := cleanInput(reader.Text())
-		splitText := strings.Split(text, " ")
-		commandName := splitText[0]
-		arguments := splitText[1:]
-
-		// interpret commands
-		if command, exists := commands[commandName]; exists {
-			err := command.callback(arguments...)
-			if err != nil {
-				errorHandler(err)
-			}
-		} else {
-			commandNotRecognized()
+	for {
+		line, err := lineEditor.ReadLine("Pokedex > ")
+		if errors.Is(err, lineedit.ErrInterrupted) {
+			continue
+		}
+		if err != nil {
+			break
+		}
+
+		lineEditor.History.Add(line)
+		err = lineEditor.History.Save(historyFilePath())
+		if err != nil {
+			errorHandler(err)
+		}
+
+		err = executeLine(commands, line)
+		if err != nil {
+			errorHandler(err)
 		}
+	}
+}
 
-		fmt.Print("Pokedex > ")
+// executeLine tokenizes a line of input and runs the command
+func executeLine(commands map[string]cliCommand, line string) error {
+	tokens, err := cli.Tokenize(line)
+	if err != nil {
+		return err
 	}
+
+	return executeCommand(commands, tokens)
+}
+
+// executeCommand parses the arguments of a tokenized command against the
+// command's spec and runs the command
+func executeCommand(commands map[string]cliCommand, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	// interpret commands
+	commandName := cleanInput(tokens[0])
+	command, exists := findCommand(commands, commandName)
+	if !exists {
+		return unknownCommandError(commands, commandName)
+	}
+
+	args, err := command.spec.Parse(tokens[1:])
+	if err != nil {
+		return fmt.Errorf("%v\nusage: %s", err, command.synopsis())
+	}
+
+	err = command.callback(args)
+	if saveErr := saveTrainer(); saveErr != nil {
+		errorHandler(saveErr)
+	}
+	return err
 }
 
 func initializeCliCommands() map[string]cliCommand {
-	return map[string]cliCommand{
+	// alias, macro and the macros themselves change or run commands from
+	// the table they are part of
+	var commands map[string]cliCommand
+	commands = map[string]cliCommand{
 		"help": {
 			name:        "help",
-			description: "Display a help message",
-			callback:    commandHelp,
+			description: "Display a help message, or detailed help for a command",
+			aliases:     []string{"?"},
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "command", Usage: "the command to display detailed help for"},
+			}},
+			examples: []string{"help", "help battle"},
+			callback: commandHelp,
+			complete: completeCommandNames,
 		},
 		"exit": {
 			name:        "exit",
 			description: "Exit the Pokedex",
+			aliases:     []string{"quit"},
 			callback:    commandExit,
 		},
 		"map": {
@@ -71,27 +204,292 @@ func initializeCliCommands() map[string]cliCommand {
 			description: "Display the previous 20 location areas",
 			callback:    commandMapb,
 		},
+		"regions": {
+			name:        "regions",
+			description: "Displays the names of all regions",
+			callback:    commandRegions,
+		},
+		"region": {
+			name:        "region",
+			description: "Displays the locations of a region, such as towns and routes",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "region", Required: true, Usage: "the name of the region"},
+			}},
+			examples: []string{"region kanto"},
+			callback: commandRegion,
+			complete: completeRegions,
+		},
+		"location": {
+			name:        "location",
+			description: "Displays the areas of a location that can be explored",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "location", Required: true, Usage: "the name of the location"},
+			}},
+			examples: []string{"location kanto-route-1"},
+			callback: commandLocation,
+			complete: completeLocations,
+		},
 		"explore": {
 			name:        "explore",
-			description: "Display the encountered Pokemon found at given location area",
-			callback:    commandExplore,
+			description: "Display the encountered Pokemon found at given location area, or at the one you are in",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "location-area", Variadic: true, Usage: "the name of a location area listed by map, with words separated by spaces or hyphens, by default the one you are in"},
+			}},
+			examples: []string{"explore", "explore pastoria-city-area", "explore canalave city"},
+			callback: commandExplore,
+			complete: completeLocationAreas,
+		},
+		"travel": {
+			name:        "travel",
+			description: "Travels to a neighboring location area, or starts your journey at any location area",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "location-area", Required: true, Variadic: true, Usage: "the name of a location area next to the one you are in, with words separated by spaces or hyphens"},
+			}},
+			examples: []string{"travel kanto-route-1-area", "travel viridian city"},
+			callback: commandTravel,
+			complete: completeTravel,
+		},
+		"look": {
+			name:        "look",
+			description: "Describes the location area you are in, its wild Pokemon and where you can travel to",
+			callback:    commandLook,
 		},
 		"catch": {
 			name:        "catch",
 			description: "Captures a Pokemon based on higher experience points making it more difficult",
-			callback:    commandCatch,
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "pokemon", Required: true, Variadic: true, Usage: "the name of the Pokemon to throw a Pokeball at, with words separated by spaces or hyphens"},
+			}},
+			examples: []string{"catch pikachu", "catch mr mime"},
+			callback: commandCatch,
+			complete: completeExploredPokemon,
 		},
 		"inspect": {
 			name:        "inspect",
-			description: "Inspects a captured Pokemon and displays its information",
-			callback:    commandInspect,
+			description: "Inspects a captured Pokemon by ID or name and displays its information, or what is known about a Pokemon you have only seen",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "pokemon", Required: true, CaseSensitive: true, Usage: "the ID, nickname or name of one of your Pokemon, or the name of a Pokemon you have seen"},
+			}},
+			examples: []string{"inspect pikachu", "inspect #3"},
+			callback: commandInspect,
+			complete: completeInspect,
 		},
 		"pokedex": {
 			name:        "pokedex",
-			description: "displays the names of all captured Pokemon",
-			callback:    commandPokedex,
+			description: "displays the names of all captured Pokemon, optionally filtered by a query and sorted, and how many species you have seen and caught",
+			aliases:     []string{"dex"},
+			spec: cli.Spec{
+				Flags: []cli.Flag{
+					{Name: "type", Usage: "only show Pokemon of the type"},
+					{Name: "ability", Usage: "only show Pokemon that can have the ability"},
+					{Name: "generation", Kind: cli.Int, Usage: "only show Pokemon introduced in the generation"},
+					{Name: "min-stat", Usage: "only show Pokemon with base stats of at least the given values, such as speed=100,attack=80"},
+					{Name: "sort", Choices: pokedexSortKeys, Usage: "what to sort by, bst being the base stat total"},
+					{Name: "desc", Kind: cli.Bool, Usage: "sort in descending order"},
+				},
+				Args: []cli.Arg{
+					{Name: "query", Variadic: true, Usage: "comparisons of " + strings.Join(pokedexFields, ", ") + " using =, !=, <, <=, > or >=, combined with and, or, not and parentheses"},
+				},
+			},
+			examples: []string{
+				"pokedex --sort speed --desc",
+				"pokedex --type fire --min-stat speed=100",
+				"pokedex --generation 3 --ability levitate",
+				`pokedex "type=water and (attack>=100 or special-attack>=100)"`,
+			},
+			callback: commandPokedex,
+		},
+		"where": {
+			name:        "where",
+			description: "Lists the location areas where a Pokemon can be found in the wild, with methods, levels and chances",
+			spec: cli.Spec{
+				Flags: []cli.Flag{
+					{Name: "version", Usage: "only list encounters in the game version, such as red or emerald"},
+				},
+				Args: []cli.Arg{
+					{Name: "pokemon", Required: true, CaseSensitive: true, Usage: "the name of a Pokemon, or the ID or nickname of one of yours"},
+				},
+			},
+			examples: []string{"where pikachu", "where --version emerald zigzagoon"},
+			callback: commandWhere,
+			complete: completeSprite,
+		},
+		"progress": {
+			name:        "progress",
+			description: "Shows how much of the National Dex you have caught, by generation and region",
+			spec: cli.Spec{Flags: []cli.Flag{
+				{Name: "missing", Kind: cli.Bool, Usage: "list the species you have not caught"},
+				{Name: "generation", Kind: cli.Int, Usage: "list the species you have not caught from the generation"},
+				{Name: "where", Kind: cli.Bool, Usage: "list where the missing species of a generation can be found, with --generation"},
+			}},
+			examples: []string{"progress", "progress --missing", "progress --generation 1", "progress --where --generation 3"},
+			callback: commandProgress,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Displays the effect of an ability and the Pokemon that can have it",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "ability", Required: true, Usage: "the name of the ability"},
+			}},
+			examples: []string{"ability levitate"},
+			callback: commandAbility,
+		},
+		"sprite": {
+			name:        "sprite",
+			description: "Draws the sprite of a Pokemon in the terminal",
+			spec: cli.Spec{
+				Flags: []cli.Flag{
+					{Name: "shiny", Kind: cli.Bool, Usage: "draw the shiny coloring"},
+					{Name: "back", Kind: cli.Bool, Usage: "draw the Pokemon from behind"},
+				},
+				Args: []cli.Arg{
+					{Name: "pokemon", Required: true, CaseSensitive: true, Usage: "the ID, nickname or name of one of your Pokemon, or the name of any Pokemon"},
+				},
+			},
+			examples: []string{"sprite pikachu", "sprite --shiny --back #2"},
+			callback: commandSprite,
+			complete: completeSprite,
+		},
+		"party": {
+			name:        "party",
+			description: "Displays the Pokemon in your party and storage boxes",
+			callback:    commandParty,
+		},
+		"deposit": {
+			name:        "deposit",
+			description: "Moves a party Pokemon, by ID or name, into a storage box",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "pokemon", Required: true, CaseSensitive: true, Usage: "the ID, nickname or name of a party Pokemon"},
+			}},
+			examples: []string{"deposit #2"},
+			callback: commandDeposit,
+			complete: completeFirstCaughtPokemon,
+		},
+		"withdraw": {
+			name:        "withdraw",
+			description: "Moves a boxed Pokemon, by ID or name, into your party",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "pokemon", Required: true, CaseSensitive: true, Usage: "the ID, nickname or name of a boxed Pokemon"},
+			}},
+			examples: []string{"withdraw #2"},
+			callback: commandWithdraw,
+			complete: completeFirstCaughtPokemon,
+		},
+		"swap": {
+			name:        "swap",
+			description: "Swaps the places of two of your Pokemon, by ID or name",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "first", Required: true, CaseSensitive: true, Usage: "the ID, nickname or name of a party Pokemon"},
+				{Name: "second", Required: true, CaseSensitive: true, Usage: "the ID, nickname or name of a party or boxed Pokemon"},
+			}},
+			examples: []string{"swap #1 #4", "swap pikachu pidgey"},
+			callback: commandSwap,
+			complete: completeCaughtPokemon,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Gives one of your Pokemon a nickname",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "pokemon", Required: true, CaseSensitive: true, Usage: "the ID, nickname or name of one of your Pokemon"},
+				{Name: "nickname", Required: true, CaseSensitive: true, Usage: "the new nickname, quoted if it contains spaces"},
+			}},
+			examples: []string{`nickname #1 "Mr Sparky"`},
+			callback: commandNickname,
+			complete: completeFirstCaughtPokemon,
+		},
+		"tui": {
+			name:        "tui",
+			description: "Browses location areas and their Pokemon in a full-screen interface",
+			callback:    commandTUI,
+		},
+		"battle": {
+			name:        "battle",
+			description: "Battles one of your Pokemon against a comma separated team of opponents, or a wild Pokemon",
+			aliases:     []string{"fight"},
+			spec: cli.Spec{
+				Flags: []cli.Flag{
+					{Name: "ai", Default: defaultBattleAI, Choices: battle.StrategyNames(), Usage: "how the opponent chooses its moves"},
+					{Name: "moves", Usage: "comma separated moves to make in turn, by number or run, instead of choosing each turn; needed in scripts"},
+					{Name: "seed", Kind: cli.Int, Usage: "the seed of the battle's random numbers, so the same seed and moves replay the same battle (default random)"},
+				},
+				Args: []cli.Arg{
+					{Name: "mine", Required: true, CaseSensitive: true, Usage: "the ID, nickname or name of one of your Pokemon"},
+					{Name: "opponent", Usage: "comma separated opponents, or wild for a Pokemon found at the location area you travelled to (default wild)"},
+					{Name: "level", Kind: cli.Int, Usage: "the level of the opponents (default the level of your Pokemon, or for a wild Pokemon a level it is found at)"},
+				},
+			},
+			examples: []string{"battle pikachu", "battle --ai switcher pikachu geodude,starmie 30", "battle --seed 42 pikachu wild 10", "battle --moves 1,1,2,run pikachu"},
+			callback: commandBattle,
+			complete: completeBattle,
+		},
+		"config": {
+			name:        "config",
+			description: "Lists, shows or changes the settings saved in the config file",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "action", Required: true, Usage: "list, get, set or unset"},
+				{Name: "name", Usage: "the name of the setting"},
+				{Name: "value", CaseSensitive: true, Usage: "the new value of the setting"},
+			}},
+			examples: []string{"config list", "config get page-size", "config set cache-interval 10m", "config unset output"},
+			callback: commandConfig,
+			complete: completeConfig,
+		},
+		"prefetch": {
+			name:        "prefetch",
+			description: "Fills the cache with the location areas listed by map, and optionally their details and Pokemon, so browsing them is fast; Ctrl-C stops it",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "depth", Usage: "areas for the pages of map (the default), details for every location area too, pokemon for their Pokemon as well"},
+			}},
+			examples: []string{"prefetch", "prefetch details", "prefetch pokemon"},
+			callback: commandPrefetch,
+			complete: completePrefetch,
+		},
+		"language": {
+			name:        "language",
+			description: "Shows or changes the language Pokemon and location area names are displayed in",
+			spec: cli.Spec{Args: []cli.Arg{
+				{Name: "code", CaseSensitive: true, Usage: "the language code, one of " + strings.Join(pokemon.Languages, ", ")},
+			}},
+			examples: []string{"language", "language ja", "language en"},
+			callback: commandLanguage,
+			complete: completeLanguage,
+		},
+		"alias": {
+			name:        "alias",
+			description: "Lists, defines or deletes your own names for commands",
+			spec: cli.Spec{
+				Flags: []cli.Flag{
+					{Name: "delete", Kind: cli.Bool, Usage: "delete the alias"},
+				},
+				Args: []cli.Arg{
+					{Name: "name", Usage: "the alias"},
+					{Name: "command", Usage: "the command the alias runs"},
+				},
+			},
+			examples: []string{"alias ex explore", "alias --delete ex"},
+			callback: func(args cli.Args) error { return commandAlias(commands, args) },
+			complete: completeAlias,
+		},
+		"macro": {
+			name:        "macro",
+			description: "Lists, defines or deletes commands that run several commands, with $1, $2, ... replaced by their arguments",
+			spec: cli.Spec{
+				Flags: []cli.Flag{
+					{Name: "delete", Kind: cli.Bool, Usage: "delete the macro"},
+				},
+				Args: []cli.Arg{
+					{Name: "name", Usage: "the name of the macro"},
+					{Name: "body", Variadic: true, CaseSensitive: true, Separators: true, Usage: "the semicolon separated commands, after an optional ="},
+				},
+				FlagsFirst: true,
+			},
+			examples: []string{"macro hunt = explore $1; catch $2", "hunt canalave-city-area tentacool", "macro fire = pokedex --type fire", "macro --delete hunt"},
+			callback: func(args cli.Args) error { return commandMacro(commands, args) },
 		},
 	}
+
+	addUserCommands(commands)
+	return commands
 }
 
 func cleanInput(text string) string {
@@ -104,149 +502,243 @@ func errorHandler(err error) {
 	fmt.Fprintln(os.Stderr, err)
 }
 
-func commandNotRecognized() {
-	fmt.Fprintln(os.Stderr, "command not recognized")
-}
-
-func commandHelp(parameters ...string) error {
-	fmt.Println()
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Println("Usage:")
-	fmt.Println()
-
-	commands := initializeCliCommands()
-	sortedKeys := sortKeys(commands)
-	for _, key := range sortedKeys {
-		command := commands[key]
-		fmt.Printf("%s: %s\n", command.name, command.description)
-	}
-
-	fmt.Println()
-
-	return nil
-}
-
-func commandExit(parameters ...string) error {
+func commandExit(args cli.Args) error {
 	os.Exit(0)
 	return nil
 }
 
-func commandMap(parameters ...string) error {
+func commandMap(args cli.Args) error {
 	locations, err := pokemonAPI.GetLocationAreas(pokemon.Next)
 	if err != nil {
 		return err
 	}
 
-	for _, result := range locations.Results {
-		fmt.Println(result.Name)
-	}
-
-	return nil
+	return displayLocationAreas(locations)
 }
 
-func commandMapb(parameters ...string) error {
+func commandMapb(args cli.Args) error {
 	locations, err := pokemonAPI.GetLocationAreas(pokemon.Previous)
 	if err != nil {
 		return err
 	}
 
-	for _, result := range locations.Results {
-		fmt.Println(result.Name)
-	}
-
-	return nil
+	return displayLocationAreas(locations)
 }
 
-func commandExplore(parameters ...string) error {
-	if len(parameters) == 0 {
-		return errors.New("No location area name was entered")
+func commandExplore(args cli.Args) error {
+	locationArea := strings.Join(args.List("location-area"), " ")
+	if locationArea == "" {
+		locationArea = currentTrainer.Location
+	}
+	if locationArea == "" {
+		return errors.New("Explore a location area listed by map to start your journey there")
+	}
+	locationArea, err := resolveName("location area", locationArea, pokemonAPI.GetLocationAreaNames)
+	if err != nil {
+		return err
+	}
+	location, err := exploreLocationArea(locationArea)
+	if err != nil {
+		return err
 	}
 
-	locationArea := parameters[0]
+	return emit(exploreOutput{location}, func() {
+		fmt.Println("Exploring " + locationAreaName(locationArea) + "...")
+		fmt.Println("Found Pokemon:")
+		fetchPokemonNames(lastExploredPokemon)
+		for _, pokemonEncounter := range location.PokemonEncounters {
+			fmt.Println(" - " + pokemonName(pokemonEncounter.Pokemon.Name))
+		}
+	})
+}
+
+// exploreLocationArea fetches the location area, remembering its Pokemon
+// for completion.  A trainer who has not started their journey starts it
+// there; otherwise only travel moves the trainer.
+func exploreLocationArea(locationArea string) (pokemon.LocationArea, error) {
 	location, err := pokemonAPI.GetLocationArea(locationArea)
 	if err != nil {
-		return err
+		return pokemon.LocationArea{}, err
 	}
 
-	fmt.Println("Exploring " + locationArea + "...")
-	fmt.Println("Found Pokemon:")
+	if currentTrainer.Location == "" {
+		currentTrainer.Location = locationArea
+	}
+	seenLocationAreas[locationArea] = true
+	lastExploredPokemon = lastExploredPokemon[:0]
+	now := time.Now()
 	for _, pokemonEncounter := range location.PokemonEncounters {
-		fmt.Println(" - " + pokemonEncounter.Pokemon.Name)
+		lastExploredPokemon = append(lastExploredPokemon, pokemonEncounter.Pokemon.Name)
+		currentTrainer.See(pokemonEncounter.Pokemon.Name, locationArea, now)
 	}
 
-	return nil
+	return location, nil
 }
 
-func commandCatch(parameters ...string) error {
-	if len(parameters) <= 0 {
-		return errors.New("No Pokemon name was entered")
+func commandCatch(args cli.Args) error {
+	name, err := resolveName("Pokemon", strings.Join(args.List("pokemon"), " "), pokemonAPI.GetPokemonNames)
+	if err != nil {
+		return err
 	}
-
-	name := parameters[0]
-	fmt.Printf("Throwing a Pokeball at %s...\n", name)
-
-	pokemon, err := pokemonAPI.Capture(name)
+	local := pokemonName(name)
+	fmt.Printf("Throwing a Pokeball at %s...\n", local)
+	caught, boxed, err := catchPokemon(name)
 	if err != nil {
 		return err
 	}
-	if pokemon == nil {
-		fmt.Printf("%s escaped!\n", name)
+
+	if caught == nil {
+		fmt.Printf("%s escaped!\n", local)
 	} else {
-		fmt.Printf("%s was caught!\n", name)
+		fmt.Printf("%s was caught at level %d! (ID #%d)\n", local, caught.Level, caught.ID)
+		if boxed {
+			fmt.Printf("Your party is full, so %s was sent to a box.\n", local)
+		}
 		fmt.Printf("You may now inspect it with the inspect command.\n")
-		pokedex[name] = *pokemon
 	}
 
 	return nil
 }
 
-func commandInspect(parameters ...string) error {
-	if len(parameters) <= 0 {
-		return errors.New("No Pokemon name was entered")
+// catchPokemon throws a Pokeball at the Pokemon and adds it to the trainer's
+// Pokemon if caught.  It returns nil if the Pokemon escaped, and whether
+// the Pokemon was sent to a box because the party is full.
+func catchPokemon(name string) (*trainer.CaughtPokemon, bool, error) {
+	pokemon, err := pokemonAPI.Capture(name)
+	if err != nil {
+		return nil, false, err
+	}
+	if pokemon == nil {
+		currentTrainer.See(strings.ToLower(name), currentTrainer.Location, time.Now())
+		return nil, false, nil
 	}
 
-	name := parameters[0]
-	if pokemon, exists := pokedex[name]; exists {
-		displayPokemonInfo(pokemon)
-	} else {
-		fmt.Println("you have not caught that pokemon")
+	caught, err := newCaughtPokemon(*pokemon)
+	if err != nil {
+		return nil, false, err
 	}
+	caught, boxed := currentTrainer.Catch(caught)
+	return &caught, boxed, nil
+}
 
-	return nil
+func commandInspect(args cli.Args) error {
+	// nicknames are matched as typed, species by their normalized name, and
+	// names in the current language only when neither matches
+	ref := args.String("pokemon")
+	name := normalizeName(ref)
+	caught, err := currentTrainer.Find(ref)
+	if err != nil {
+		caught, err = currentTrainer.Find(name)
+	}
+	if _, isID := trainer.ParseID(ref); err != nil && !isID && !currentTrainer.HasSeen(name) {
+		name = normalizeName(fromLocalName(ref))
+		caught, err = currentTrainer.Find(name)
+	}
+	if err != nil && !currentTrainer.HasCaught(name) {
+		seen, found := currentTrainer.FindSeen(name)
+		if !found {
+			if suggestions := fuzzy.Suggest(normalizeName(ref), completeInspect(0), maxSuggestions); len(suggestions) > 0 {
+				return unknownNameError("Pokemon", ref, suggestions)
+			}
+			return err
+		}
+		return inspectSeen(seen)
+	}
+	if err != nil {
+		return err
+	}
+
+	return emit(newInspectOutput(*caught), func() {
+		displayPokemonInfo(*caught)
+	})
 }
 
-func commandPokedex(parameters ...string) error {
-	fmt.Printf("Your Pokedex:\n")
-	for name, _ := range pokedex {
-		fmt.Printf(" - %v\n", name)
+func commandAbility(args cli.Args) error {
+	name := args.String("ability")
+	ability, err := pokemonAPI.GetAbility(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Ability: %v\n", ability.Name)
+	fmt.Printf("Effect:\n")
+	effect := ability.EnglishEffect()
+	if effect == "" {
+		effect = "no English effect description available"
+	}
+	fmt.Printf("  %v\n", strings.ReplaceAll(effect, "\n", "\n  "))
+
+	fmt.Printf("Pokemon:\n")
+	for _, abilityPokemon := range ability.Pokemon {
+		if abilityPokemon.IsHidden {
+			fmt.Printf("  - %v (hidden)\n", abilityPokemon.Pokemon.Name)
+		} else {
+			fmt.Printf("  - %v\n", abilityPokemon.Pokemon.Name)
+		}
 	}
 
 	return nil
 }
 
-func displayPokemonInfo(pokemon pokemon.Pokemon) {
-	fmt.Printf("Name: %v\n", pokemon.Name)
-	fmt.Printf("Height: %v\n", pokemon.Height)
-	fmt.Printf("Weight: %v\n", pokemon.Weight)
+func displayPokemonInfo(caught trainer.CaughtPokemon) {
+	pokemon := caught.Species
 
-	fmt.Printf("Stats:\n")
-	for _, stat := range pokemon.Stats {
-		fmt.Printf("  -%v: %v\n", stat.Stat.Name, stat.BaseStat)
+	caughtOn := caught.CaughtOn.Format(time.DateOnly)
+	if caught.CaughtAt != "" {
+		caughtOn = fmt.Sprintf("%v on %v", caught.CaughtAt, caughtOn)
 	}
 
-	fmt.Printf("Types:\n")
+	types := make([]string, 0, len(pokemon.Types))
 	for _, pokemonType := range pokemon.Types {
-		fmt.Printf("  - %v\n", pokemonType.Type.Name)
+		types = append(types, renderer.Type(pokemonType.Type.Name))
 	}
-}
 
-func sortKeys(mapToSort map[string]cliCommand) []string {
-	keys := make([]string, 0, len(mapToSort))
+	abilities := make([]string, 0, len(pokemon.Abilities))
+	for _, pokemonAbility := range pokemon.Abilities {
+		if pokemonAbility.IsHidden {
+			abilities = append(abilities, pokemonAbility.Ability.Name+renderer.Dim(" (hidden)"))
+		} else {
+			abilities = append(abilities, pokemonAbility.Ability.Name)
+		}
+	}
+
+	name := caught.Nickname
+	if name == "" {
+		name = pokemonName(pokemon.Name)
+	}
+	fmt.Printf("%v %v\n", renderer.Bold(name), renderer.Dim(fmt.Sprintf("#%v", caught.ID)))
+	details := [][]string{}
+	if caught.Nickname != "" {
+		details = append(details, []string{"Species:", pokemonName(pokemon.Name)})
+	}
+	details = append(details,
+		[]string{"Level:", fmt.Sprint(caught.Level)},
+		[]string{"Experience:", fmt.Sprintf("%v (%v to next level)", caught.Experience, caught.ExperienceToNextLevel())},
+		[]string{"Nature:", caught.Nature.Name},
+		[]string{"Caught:", caughtOn},
+		[]string{"Height:", fmt.Sprint(pokemon.Height)},
+		[]string{"Weight:", fmt.Sprint(pokemon.Weight)},
+		[]string{"Types:", strings.Join(types, " ")},
+		[]string{"Abilities:", strings.Join(abilities, ", ")},
+	)
+	renderer.Table(os.Stdout, nil, details)
+
+	stats := caught.Stats()
+	header := []string{"Stat", "Value", "Base", "IV", "EV"}
+	rows := make([][]string, 0, len(pokemon.Stats))
+	for _, stat := range pokemon.Stats {
+		name := stat.Stat.Name
+		rows = append(rows, []string{name, fmt.Sprint(stats.Get(name)), fmt.Sprint(stat.BaseStat), fmt.Sprint(caught.IVs.Get(name)), fmt.Sprint(caught.EVs.Get(name))})
+	}
 
-	for key := range mapToSort {
-		keys = append(keys, key)
+	// the bars of the base stats fill whatever room the terminal has left
+	if barWidth := min(renderer.Width-render.TableWidth(header, rows)-2, maxStatBarWidth); barWidth >= minStatBarWidth {
+		header = append(header, "")
+		for i, stat := range pokemon.Stats {
+			rows[i] = append(rows[i], renderer.StatBar(stat.BaseStat, barWidth))
+		}
 	}
-	sort.Strings(keys)
 
-	return keys
+	fmt.Println()
+	renderer.Table(os.Stdout, header, rows)
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/fuzzy"
+)
+
+// maxSuggestions is the number of names suggested for a name that is not
+// known
+const maxSuggestions = 3
+
+// normalizeName turns a name as typed, such as "Canalave City", into the
+// form the API uses, canalave-city
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "-")
+}
+
+// resolveName returns the known name the input refers to, also accepting
+// location area names without their -area suffix and names in the current
+// language.  Unknown names are an error suggesting the closest known ones.  If the names cannot be
+// listed the input is used as it is.
+func resolveName(kind string, input string, list func() ([]string, error)) (string, error) {
+	name := normalizeName(input)
+	names, err := list()
+	if err != nil {
+		return name, nil
+	}
+
+	known := make(map[string]bool, len(names))
+	for _, n := range names {
+		known[n] = true
+	}
+	switch {
+	case known[name]:
+		return name, nil
+	case known[name+"-area"]:
+		return name + "-area", nil
+	case known[fromLocalName(name)]:
+		return fromLocalName(name), nil
+	}
+
+	return "", unknownNameError(kind, input, fuzzy.Suggest(name, names, maxSuggestions))
+}
+
+// unknownNameError reports a name that is not known, suggesting the
+// names it could have been meant as
+func unknownNameError(kind string, input string, suggestions []string) error {
+	if len(suggestions) == 0 {
+		return fmt.Errorf("unknown %s %q", kind, input)
+	}
+	quoted := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		quoted = append(quoted, fmt.Sprintf("%q", suggestion))
+	}
+	if len(quoted) == 1 {
+		return fmt.Errorf("unknown %s %q, did you mean %s?", kind, input, quoted[0])
+	}
+	return fmt.Errorf("unknown %s %q, did you mean %s or %s?", kind, input, strings.Join(quoted[:len(quoted)-1], ", "), quoted[len(quoted)-1])
+}
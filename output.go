@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rkanagy/pokedexcli/internal/battle"
+	"github.com/rkanagy/pokedexcli/internal/output"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+	"github.com/rkanagy/pokedexcli/internal/trainer"
+)
+
+// outputFormat is the format commands with listings write them in
+var outputFormat output.Format = output.Text
+
+// emit writes the value in the structured output format, or calls text to
+// print it for humans when the output format is text
+func emit(value any, text func()) error {
+	if outputFormat == output.Text {
+		text()
+		return nil
+	}
+	return output.Write(os.Stdout, outputFormat, value)
+}
+
+func displayLocationAreas(locations pokemon.LocationAreas) error {
+	for _, result := range locations.Results {
+		seenLocationAreas[result.Name] = true
+	}
+
+	return emit(locationAreasOutput{locations}, func() {
+		for _, result := range locations.Results {
+			fmt.Println(result.Name)
+		}
+	})
+}
+
+// locationAreasOutput is a page of location areas as written by map and mapb
+type locationAreasOutput struct {
+	pokemon.LocationAreas
+}
+
+// Table implements output.Tabular
+func (l locationAreasOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(l.Results))
+	for _, result := range l.Results {
+		rows = append(rows, []string{result.Name, result.URL})
+	}
+	return []string{"name", "url"}, rows
+}
+
+// exploreOutput is a location area as written by explore
+type exploreOutput struct {
+	pokemon.LocationArea
+}
+
+// Table implements output.Tabular
+func (e exploreOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(e.PokemonEncounters))
+	for _, encounter := range e.PokemonEncounters {
+		minLevel, maxLevel := encounterLevels(encounter)
+		rows = append(rows, []string{e.Name, encounter.Pokemon.Name, strconv.Itoa(minLevel), strconv.Itoa(maxLevel), encounter.Pokemon.URL})
+	}
+	return []string{"location_area", "pokemon", "min_level", "max_level", "url"}, rows
+}
+
+// inspectOutput is a caught Pokemon as written by inspect
+type inspectOutput struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Nickname   string          `json:"nickname,omitempty"`
+	Level      int             `json:"level"`
+	Experience int             `json:"experience"`
+	Nature     string          `json:"nature"`
+	CaughtAt   string          `json:"caught_at,omitempty"`
+	CaughtOn   time.Time       `json:"caught_on"`
+	IVs        battle.Stats    `json:"ivs"`
+	EVs        battle.Stats    `json:"evs"`
+	Stats      battle.Stats    `json:"stats"`
+	Species    pokemon.Pokemon `json:"species"`
+}
+
+func newInspectOutput(caught trainer.CaughtPokemon) inspectOutput {
+	return inspectOutput{
+		ID:         caught.ID,
+		Name:       caught.Species.Name,
+		Nickname:   caught.Nickname,
+		Level:      caught.Level,
+		Experience: caught.Experience,
+		Nature:     caught.Nature.Name,
+		CaughtAt:   caught.CaughtAt,
+		CaughtOn:   caught.CaughtOn,
+		IVs:        caught.IVs,
+		EVs:        caught.EVs,
+		Stats:      caught.Stats(),
+		Species:    caught.Species,
+	}
+}
+
+// Table implements output.Tabular with one row per stat
+func (i inspectOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(i.Species.Stats))
+	for _, stat := range i.Species.Stats {
+		name := stat.Stat.Name
+		rows = append(rows, []string{
+			strconv.Itoa(i.ID),
+			i.Name,
+			strconv.Itoa(i.Level),
+			name,
+			strconv.Itoa(stat.BaseStat),
+			strconv.Itoa(i.IVs.Get(name)),
+			strconv.Itoa(i.EVs.Get(name)),
+			strconv.Itoa(i.Stats.Get(name)),
+		})
+	}
+	return []string{"id", "name", "level", "stat", "base", "iv", "ev", "value"}, rows
+}
+
+// pokedexEntry is a caught species as written by pokedex
+type pokedexEntry struct {
+	ID            int          `json:"id"`
+	Name          string       `json:"name"`
+	Types         []string     `json:"types"`
+	Abilities     []string     `json:"abilities"`
+	Height        int          `json:"height"`
+	Weight        int          `json:"weight"`
+	BaseStats     battle.Stats `json:"base_stats"`
+	BaseStatTotal int          `json:"base_stat_total"`
+	Caught        int          `json:"caught"`
+
+	species string
+}
+
+// pokedexOutput contains one entry per caught species, in the order they
+// were first caught unless sorted
+type pokedexOutput []pokedexEntry
+
+func newPokedexOutput(all []trainer.CaughtPokemon) pokedexOutput {
+	entries := pokedexOutput{}
+	index := make(map[string]int)
+	for _, caught := range all {
+		species := caught.Species
+		if i, found := index[species.Name]; found {
+			entries[i].Caught++
+			continue
+		}
+
+		types := make([]string, 0, len(species.Types))
+		for _, pokemonType := range species.Types {
+			types = append(types, pokemonType.Type.Name)
+		}
+		abilities := make([]string, 0, len(species.Abilities))
+		for _, pokemonAbility := range species.Abilities {
+			abilities = append(abilities, pokemonAbility.Ability.Name)
+		}
+		stats := baseStats(caught)
+		total := 0
+		for _, name := range battle.StatNames {
+			total += stats.Get(name)
+		}
+
+		index[species.Name] = len(entries)
+		entries = append(entries, pokedexEntry{
+			ID:            species.ID,
+			Name:          species.Name,
+			Types:         types,
+			Abilities:     abilities,
+			Height:        species.Height,
+			Weight:        species.Weight,
+			BaseStats:     stats,
+			BaseStatTotal: total,
+			Caught:        1,
+			species:       species.Species.Name,
+		})
+	}
+	return entries
+}
+
+// Table implements output.Tabular
+func (p pokedexOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(p))
+	for _, entry := range p {
+		types := ""
+		for i, pokemonType := range entry.Types {
+			if i > 0 {
+				types += "/"
+			}
+			types += pokemonType
+		}
+		rows = append(rows, []string{strconv.Itoa(entry.ID), entry.Name, types, strconv.Itoa(entry.Height), strconv.Itoa(entry.Weight), strconv.Itoa(entry.BaseStatTotal), strconv.Itoa(entry.Caught)})
+	}
+	return []string{"id", "name", "types", "height", "weight", "bst", "caught"}, rows
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+	"github.com/rkanagy/pokedexcli/internal/trainer"
+)
+
+const defaultCatchLevel = 5
+
+func commandParty(args cli.Args) error {
+	fmt.Printf("Your Party (%d/%d):\n", len(currentTrainer.Party), trainer.PartySize)
+	for slot, caught := range currentTrainer.Party {
+		fmt.Printf("  %d. #%d %v\n", slot+1, caught.ID, caught.Name())
+	}
+
+	for i, box := range currentTrainer.Boxes {
+		fmt.Printf("Box %d (%d/%d):\n", i+1, len(box.Pokemon), trainer.BoxSize)
+		for _, caught := range box.Pokemon {
+			fmt.Printf("  - #%d %v\n", caught.ID, caught.Name())
+		}
+	}
+
+	return nil
+}
+
+func commandDeposit(args cli.Args) error {
+	deposited, err := currentTrainer.Deposit(args.String("pokemon"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s was deposited in a box.\n", deposited.ID, deposited.Name())
+
+	return nil
+}
+
+func commandWithdraw(args cli.Args) error {
+	withdrawn, err := currentTrainer.Withdraw(args.String("pokemon"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s joined your party.\n", withdrawn.ID, withdrawn.Name())
+
+	return nil
+}
+
+func commandSwap(args cli.Args) error {
+	first, second := args.String("first"), args.String("second")
+	err := currentTrainer.Swap(first, second)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Swapped %s and %s.\n", first, second)
+
+	return nil
+}
+
+func commandNickname(args cli.Args) error {
+	caught, err := currentTrainer.Find(args.String("pokemon"))
+	if err != nil {
+		return err
+	}
+	nickname := args.String("nickname")
+	fmt.Printf("%s is now known as %s.\n", caught.Name(), nickname)
+	caught.Nickname = nickname
+
+	return nil
+}
+
+// newCaughtPokemon creates an individual of the given species with a
+// random nature, at a level within its encounter range at the current
+// location area
+func newCaughtPokemon(species pokemon.Pokemon) (trainer.CaughtPokemon, error) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	natures, err := pokemonAPI.GetNatures()
+	if err != nil {
+		return trainer.CaughtPokemon{}, err
+	}
+	if len(natures.Results) == 0 {
+		return trainer.CaughtPokemon{}, errors.New("The API did not list any natures to give the Pokemon")
+	}
+	nature, err := pokemonAPI.GetNature(natures.Results[rng.Intn(len(natures.Results))].Name)
+	if err != nil {
+		return trainer.CaughtPokemon{}, err
+	}
+
+	speciesInfo, err := pokemonAPI.GetPokemonSpecies(species.Species.Name)
+	if err != nil {
+		return trainer.CaughtPokemon{}, err
+	}
+	growthRate, err := pokemonAPI.GetGrowthRate(speciesInfo.GrowthRate.Name)
+	if err != nil {
+		return trainer.CaughtPokemon{}, err
+	}
+
+	level, err := catchLevel(species.Name, rng)
+	if err != nil {
+		return trainer.CaughtPokemon{}, err
+	}
+
+	caught := trainer.NewCaughtPokemon(species, level, nature, growthRate, rng)
+	caught.CaughtAt = currentTrainer.Location
+
+	return caught, nil
+}
+
+// catchLevel returns a random level within the Pokemon's encounter range
+// at the current location area, or the default catch level if it is not
+// encountered there
+func catchLevel(name string, rng *rand.Rand) (int, error) {
+	if currentTrainer.Location == "" {
+		return defaultCatchLevel, nil
+	}
+
+	location, err := pokemonAPI.GetLocationArea(currentTrainer.Location)
+	if err != nil {
+		return 0, err
+	}
+	for _, encounter := range location.PokemonEncounters {
+		if encounter.Pokemon.Name != name {
+			continue
+		}
+		if minLevel, maxLevel := encounterLevels(encounter); minLevel > 0 {
+			return minLevel + rng.Intn(maxLevel-minLevel+1), nil
+		}
+	}
+
+	return defaultCatchLevel, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/battle"
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+	"github.com/rkanagy/pokedexcli/internal/query"
+	"github.com/rkanagy/pokedexcli/internal/trainer"
+)
+
+// pokedexFields are the fields pokedex queries can compare
+var pokedexFields = append([]string{"name", "id", "type", "ability", "generation", "bst", "height", "weight", "caught"}, battle.StatNames...)
+
+// pokedexSortKeys are the values pokedex can be sorted by
+var pokedexSortKeys = append([]string{"name", "id", "bst", "height", "weight", "caught"}, battle.StatNames...)
+
+func commandPokedex(args cli.Args) error {
+	predicate, err := pokedexQuery(args)
+	if err != nil {
+		return err
+	}
+
+	all := newPokedexOutput(currentTrainer.All())
+	entries := pokedexOutput{}
+	for _, entry := range all {
+		matched, err := predicate.Match(entry)
+		if err != nil {
+			return err
+		}
+		if matched {
+			entries = append(entries, entry)
+		}
+	}
+
+	sortKey := args.String("sort")
+	if sortKey != "" {
+		sortPokedex(entries, sortKey, args.Bool("desc"))
+	}
+
+	return emit(entries, func() {
+		fmt.Printf("Your Pokedex:\n")
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+		fetchPokemonNames(names)
+		for _, entry := range entries {
+			if sortKey == "" || sortKey == "name" {
+				fmt.Printf(" - %v\n", pokemonName(entry.Name))
+			} else {
+				fmt.Printf(" - %v (%v %v)\n", pokemonName(entry.Name), sortKey, entry.sortValue(sortKey))
+			}
+		}
+		if len(entries) < len(all) {
+			fmt.Printf("Showing %d of %d species\n", len(entries), len(all))
+		}
+		seen, caught := seenCount()
+		fmt.Printf("Seen: %d  Caught: %d\n", seen, caught)
+	})
+}
+
+// pokedexQuery combines the query argument and the filter flags into one
+// predicate
+func pokedexQuery(args cli.Args) (query.Predicate, error) {
+	predicate, err := query.Parse(strings.Join(args.List("query"), " "), pokedexFields)
+	if err != nil {
+		return nil, err
+	}
+	predicates := []query.Predicate{predicate}
+
+	if args.IsSet("type") {
+		predicates = append(predicates, query.Compare("type", "=", args.String("type")))
+	}
+	if args.IsSet("ability") {
+		predicates = append(predicates, query.Compare("ability", "=", args.String("ability")))
+	}
+	if args.IsSet("generation") {
+		predicates = append(predicates, query.Compare("generation", "=", args.String("generation")))
+	}
+	if args.IsSet("min-stat") {
+		for _, minimum := range strings.Split(args.String("min-stat"), ",") {
+			stat, value, found := strings.Cut(minimum, "=")
+			if _, err := strconv.Atoi(value); !found || err != nil || !isStatName(stat) {
+				return nil, fmt.Errorf("invalid minimum stat %q, expected a stat and number such as speed=100", minimum)
+			}
+			predicates = append(predicates, query.Compare(stat, ">=", value))
+		}
+	}
+
+	return query.And(predicates...), nil
+}
+
+func isStatName(name string) bool {
+	for _, stat := range battle.StatNames {
+		if stat == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sortPokedex sorts the entries by the key, keeping the order they were
+// first caught in for equal entries
+func sortPokedex(entries pokedexOutput, key string, descending bool) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if descending {
+			a, b = b, a
+		}
+		if key == "name" {
+			return a.Name < b.Name
+		}
+		return a.sortValue(key) < b.sortValue(key)
+	})
+}
+
+// sortValue returns the number pokedex entries are sorted by for the key
+func (e pokedexEntry) sortValue(key string) int {
+	switch key {
+	case "id":
+		return e.ID
+	case "bst":
+		return e.BaseStatTotal
+	case "height":
+		return e.Height
+	case "weight":
+		return e.Weight
+	case "caught":
+		return e.Caught
+	}
+	return e.BaseStats.Get(key)
+}
+
+// Values implements query.Record, fetching the species from the API only
+// when its generation is needed
+func (e pokedexEntry) Values(field string) ([]string, error) {
+	switch field {
+	case "name":
+		return []string{e.Name}, nil
+	case "type":
+		return e.Types, nil
+	case "ability":
+		return e.Abilities, nil
+	case "generation":
+		species, err := pokemonAPI.GetPokemonSpecies(e.species)
+		if err != nil {
+			return nil, err
+		}
+		return []string{strconv.Itoa(pokemon.GenerationNumber(species.Generation.Name))}, nil
+	}
+	return []string{strconv.Itoa(e.sortValue(field))}, nil
+}
+
+// baseStats returns the base stats of the Pokemon
+func baseStats(caught trainer.CaughtPokemon) battle.Stats {
+	stats := battle.Stats{}
+	for _, stat := range caught.Species.Stats {
+		stats.Set(stat.Stat.Name, stat.BaseStat)
+	}
+	return stats
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// prefetchDepths are what prefetch can fetch, each including the ones
+// before it
+var prefetchDepths = []string{"areas", "details", "pokemon"}
+
+// progressBarWidth is the width of the prefetch progress bar
+const progressBarWidth = 30
+
+func commandPrefetch(args cli.Args) error {
+	depth := prefetchDepths[0]
+	if args.IsSet("depth") {
+		depth = args.String("depth")
+	}
+	if !contains(prefetchDepths, depth) {
+		return fmt.Errorf("unknown prefetch depth %q, expected one of %s", depth, strings.Join(prefetchDepths, ", "))
+	}
+	return prefetch(depth)
+}
+
+// prefetch fills the API cache up to the depth, drawing a progress bar on
+// a terminal.  Ctrl-C stops it, keeping what was fetched.
+func prefetch(depth string) error {
+	level := pokemon.PrefetchAreas
+	for i, name := range prefetchDepths {
+		if name == depth {
+			level = pokemon.PrefetchDepth(i)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fetched, err := pokemonAPI.Prefetch(ctx, level, func(stage string, done, total int) {
+		showProgress(os.Stdout, stage, done, total)
+	})
+	if errors.Is(err, context.Canceled) {
+		if renderer.Terminal {
+			fmt.Println()
+		}
+		fmt.Printf("Prefetch cancelled, %d responses were cached\n", fetched)
+		return nil
+	}
+
+	fmt.Printf("Cached %d responses, kept for %s\n", fetched, settings.Value(cacheIntervalSetting))
+	return err
+}
+
+// showProgress shows how far a stage of fetching is, as a progress bar
+// redrawn in place on a terminal, or otherwise a line once it is done
+func showProgress(w io.Writer, stage string, done, total int) {
+	switch {
+	case renderer.Terminal:
+		fmt.Fprintf(w, "\r%-14s %s %d/%d", stage, renderer.ProgressBar(done, total, progressBarWidth), done, total)
+		if done == total {
+			fmt.Fprintln(w)
+		}
+	case done == total:
+		fmt.Fprintf(w, "%s: %d/%d\n", stage, done, total)
+	}
+}
+
+// completePrefetch completes the prefetch depths
+func completePrefetch(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	return prefetchDepths
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// maxWhereAreas is the number of location areas listed for each missing
+// species before the rest are counted
+const maxWhereAreas = 3
+
+// progressGroup is the completion of a generation or region
+type progressGroup struct {
+	Name    string  `json:"name"`
+	Region  string  `json:"region,omitempty"`
+	Caught  int     `json:"caught"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// missingSpecies is a species that has not been caught yet
+type missingSpecies struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	Generation    int      `json:"generation"`
+	LocationAreas []string `json:"location_areas,omitempty"`
+}
+
+// progressOutput is the completion of the National Dex as written by
+// progress
+type progressOutput struct {
+	Caught      int              `json:"caught"`
+	Total       int              `json:"total"`
+	Percent     float64          `json:"percent"`
+	Generations []progressGroup  `json:"generations"`
+	Regions     []progressGroup  `json:"regions"`
+	Missing     []missingSpecies `json:"missing,omitempty"`
+}
+
+// Table implements output.Tabular with one row per generation
+func (p progressOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(p.Generations))
+	for _, generation := range p.Generations {
+		rows = append(rows, []string{generation.Name, generation.Region, strconv.Itoa(generation.Caught), strconv.Itoa(generation.Total), formatPercent(generation.Percent)})
+	}
+	return []string{"generation", "region", "caught", "total", "percent"}, rows
+}
+
+func commandProgress(args cli.Args) error {
+	onlyGeneration := args.Int("generation")
+	showMissing := args.Bool("missing") || args.Bool("where") || onlyGeneration != 0
+	if args.Bool("where") && onlyGeneration == 0 {
+		return errors.New("Use --generation with --where, finding every missing species takes a request each")
+	}
+
+	allSpecies, err := pokemonAPI.GetPokemonSpeciesList()
+	if err != nil {
+		return err
+	}
+	generations, err := pokemonAPI.GetGenerations()
+	if err != nil {
+		return err
+	}
+
+	caught := make(map[int]bool)
+	for _, c := range currentTrainer.All() {
+		caught[pokemon.ResourceID(c.Species.Species.URL)] = true
+	}
+
+	progress := progressOutput{Total: len(allSpecies)}
+	for _, species := range allSpecies {
+		if caught[pokemon.ResourceID(species.URL)] {
+			progress.Caught++
+		}
+	}
+	progress.Percent = percent(progress.Caught, progress.Total)
+
+	regionIndex := make(map[string]int)
+	foundGeneration := false
+	for _, resource := range generations {
+		generation, err := pokemonAPI.GetGeneration(resource.Name)
+		if err != nil {
+			return err
+		}
+
+		number := pokemon.GenerationNumber(generation.Name)
+		foundGeneration = foundGeneration || number == onlyGeneration
+		group := progressGroup{Name: strconv.Itoa(number), Region: generation.MainRegion.Name, Total: len(generation.PokemonSpecies)}
+		for _, species := range generation.PokemonSpecies {
+			id := pokemon.ResourceID(species.URL)
+			if caught[id] {
+				group.Caught++
+			} else if showMissing && (onlyGeneration == 0 || onlyGeneration == number) {
+				progress.Missing = append(progress.Missing, missingSpecies{ID: id, Name: species.Name, Generation: number})
+			}
+		}
+		group.Percent = percent(group.Caught, group.Total)
+		progress.Generations = append(progress.Generations, group)
+
+		i, found := regionIndex[group.Region]
+		if !found {
+			i = len(progress.Regions)
+			regionIndex[group.Region] = i
+			progress.Regions = append(progress.Regions, progressGroup{Name: group.Region})
+		}
+		progress.Regions[i].Caught += group.Caught
+		progress.Regions[i].Total += group.Total
+	}
+	if onlyGeneration != 0 && !foundGeneration {
+		return fmt.Errorf("There is no generation %d", onlyGeneration)
+	}
+	for i := range progress.Regions {
+		progress.Regions[i].Percent = percent(progress.Regions[i].Caught, progress.Regions[i].Total)
+	}
+
+	sort.Slice(progress.Missing, func(i, j int) bool {
+		return progress.Missing[i].ID < progress.Missing[j].ID
+	})
+	if args.Bool("where") {
+		ids := make([]string, 0, len(progress.Missing))
+		for _, missing := range progress.Missing {
+			ids = append(ids, strconv.Itoa(missing.ID))
+		}
+		encounters, err := pokemonAPI.GetPokemonEncountersBatch(ids)
+		if err != nil {
+			return err
+		}
+		for i := range progress.Missing {
+			progress.Missing[i].LocationAreas = encounterAreas(encounters[i])
+		}
+	}
+
+	return emit(progress, func() {
+		displayProgress(progress, showMissing, args.Bool("where"))
+	})
+}
+
+// encounterAreas returns the names of the location areas of the encounters
+func encounterAreas(encounters []pokemon.LocationAreaEncounter) []string {
+	areas := make([]string, 0, len(encounters))
+	for _, encounter := range encounters {
+		areas = append(areas, encounter.LocationArea.Name)
+	}
+	return areas
+}
+
+func displayProgress(progress progressOutput, showMissing bool, showWhere bool) {
+	fmt.Printf("National Dex: %d/%d (%s)\n\n", progress.Caught, progress.Total, formatPercent(progress.Percent))
+
+	_, rows := progress.Table()
+	renderer.Table(os.Stdout, []string{"Generation", "Region", "Caught", "Total", "Complete"}, rows)
+
+	fmt.Println()
+	rows = make([][]string, 0, len(progress.Regions))
+	for _, region := range progress.Regions {
+		rows = append(rows, []string{region.Name, strconv.Itoa(region.Caught), strconv.Itoa(region.Total), formatPercent(region.Percent)})
+	}
+	renderer.Table(os.Stdout, []string{"Region", "Caught", "Total", "Complete"}, rows)
+
+	if !showMissing {
+		return
+	}
+	fmt.Printf("\nMissing species:\n")
+	if len(progress.Missing) == 0 {
+		fmt.Println("  none, you caught them all!")
+	}
+	for _, missing := range progress.Missing {
+		if !showWhere {
+			fmt.Printf(" - #%d %s\n", missing.ID, missing.Name)
+			continue
+		}
+		fmt.Printf(" - #%d %s: %s\n", missing.ID, missing.Name, describeAreas(missing.LocationAreas))
+	}
+}
+
+// describeAreas lists the first few location areas and counts the rest
+func describeAreas(areas []string) string {
+	switch {
+	case len(areas) == 0:
+		return "not found in the wild"
+	case len(areas) <= maxWhereAreas:
+		return strings.Join(areas, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(areas[:maxWhereAreas], ", "), len(areas)-maxWhereAreas)
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(value, 'f', 1, 64) + "%"
+}
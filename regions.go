@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// seenRegions contains the regions listed by regions
+var seenRegions = make(map[string]bool)
+
+// seenLocations contains the locations listed by region
+var seenLocations = make(map[string]bool)
+
+// regionsOutput is the list of regions as written by regions
+type regionsOutput []pokemon.NamedAPIResource
+
+// Table implements output.Tabular
+func (r regionsOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r))
+	for _, region := range r {
+		rows = append(rows, []string{region.Name, region.URL})
+	}
+	return []string{"name", "url"}, rows
+}
+
+// regionOutput is a region and its locations as written by region
+type regionOutput struct {
+	pokemon.Region
+}
+
+// Table implements output.Tabular
+func (r regionOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Locations))
+	for _, location := range r.Locations {
+		rows = append(rows, []string{r.Name, location.Name, location.URL})
+	}
+	return []string{"region", "location", "url"}, rows
+}
+
+// locationOutput is a location and its areas as written by location
+type locationOutput struct {
+	pokemon.Location
+}
+
+// Table implements output.Tabular
+func (l locationOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(l.Areas))
+	for _, area := range l.Areas {
+		rows = append(rows, []string{l.Region.Name, l.Name, area.Name, area.URL})
+	}
+	return []string{"region", "location", "location_area", "url"}, rows
+}
+
+func commandRegions(args cli.Args) error {
+	regions, err := pokemonAPI.GetRegions()
+	if err != nil {
+		return err
+	}
+
+	for _, region := range regions {
+		seenRegions[region.Name] = true
+	}
+	return emit(regionsOutput(regions), func() {
+		for _, region := range regions {
+			fmt.Println(region.Name)
+		}
+	})
+}
+
+func commandRegion(args cli.Args) error {
+	region, err := pokemonAPI.GetRegion(args.String("region"))
+	if err != nil {
+		return err
+	}
+
+	for _, location := range region.Locations {
+		seenLocations[location.Name] = true
+	}
+	return emit(regionOutput{region}, func() {
+		fmt.Printf("%v %v\n", renderer.Bold(region.Name), renderer.Dim("("+region.MainGeneration.Name+")"))
+		fmt.Printf("Locations:\n")
+		for _, location := range region.Locations {
+			fmt.Printf(" - %v\n", location.Name)
+		}
+	})
+}
+
+func commandLocation(args cli.Args) error {
+	location, err := pokemonAPI.GetLocation(args.String("location"))
+	if err != nil {
+		return err
+	}
+
+	for _, area := range location.Areas {
+		seenLocationAreas[area.Name] = true
+	}
+	return emit(locationOutput{location}, func() {
+		if location.Region.Name != "" {
+			fmt.Printf("%v %v\n", renderer.Bold(location.Name), renderer.Dim("in "+location.Region.Name))
+		} else {
+			fmt.Println(renderer.Bold(location.Name))
+		}
+		fmt.Printf("Areas:\n")
+		if len(location.Areas) == 0 {
+			fmt.Println("  none, there is nothing to explore here")
+		}
+		for _, area := range location.Areas {
+			fmt.Printf(" - %v\n", area.Name)
+		}
+	})
+}
+
+// completeRegions completes the regions listed by regions
+func completeRegions(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	return sortedSet(seenRegions)
+}
+
+// completeLocations completes the locations listed by region
+func completeLocations(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	return sortedSet(seenLocations)
+}
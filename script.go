@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/lineedit"
+)
+
+// runOneShot runs a single command given on the command line and returns
+// the exit code of the program
+func runOneShot(commands map[string]cliCommand, tokens []string) int {
+	// the shell has already removed the quotes, so a semicolon of its own,
+	// typed as \; or ';', separates commands as in the REPL
+	for i, token := range tokens {
+		if token == ";" {
+			tokens[i] = cli.Separator
+		}
+	}
+	err := executeCommand(commands, tokens)
+	if err != nil {
+		errorHandler(err)
+		return 1
+	}
+	return 0
+}
+
+// runScriptFile runs the commands in a file, one per line, and returns the
+// exit code of the program
+func runScriptFile(commands map[string]cliCommand, path string, failFast bool) int {
+	file, err := os.Open(path)
+	if err != nil {
+		errorHandler(err)
+		return 1
+	}
+	defer file.Close()
+
+	lineEditor = lineedit.NewReader(file, os.Stdout)
+	return runScript(commands, failFast)
+}
+
+// runScript runs commands read by the line editor without displaying
+// prompts, skipping blank lines and lines starting with '#'.  Every line is
+// a command, so commands that would prompt for input, such as battle, take
+// their answers from flags instead.  With failFast the first failing command stops the script with a
+// non-zero exit code.
+func runScript(commands map[string]cliCommand, failFast bool) int {
+	lineNumber := 0
+	for {
+		line, err := lineEditor.ReadLine("")
+		if errors.Is(err, io.EOF) {
+			return 0
+		}
+		if err != nil {
+			errorHandler(err)
+			return 1
+		}
+		lineNumber++
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		err = executeLine(commands, line)
+		if err != nil {
+			errorHandler(fmt.Errorf("line %d: %v", lineNumber, err))
+			if failFast {
+				return 1
+			}
+		}
+	}
+}
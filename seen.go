@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rkanagy/pokedexcli/internal/trainer"
+)
+
+// seenOutput is what is known about a Pokemon that has been seen but not
+// caught, as written by inspect
+type seenOutput struct {
+	Name   string    `json:"name"`
+	Types  []string  `json:"types"`
+	SeenAt string    `json:"seen_at,omitempty"`
+	SeenOn time.Time `json:"seen_on"`
+}
+
+// Table implements output.Tabular
+func (s seenOutput) Table() ([]string, [][]string) {
+	return []string{"name", "types", "seen_at", "seen_on"}, [][]string{
+		{s.Name, strings.Join(s.Types, " "), s.SeenAt, s.SeenOn.Format(time.RFC3339)},
+	}
+}
+
+// inspectSeen displays the little that is known about a Pokemon that has
+// been seen but not caught, like the in-game Pokedex
+func inspectSeen(seen trainer.SeenPokemon) error {
+	pokemon, err := pokemonAPI.GetPokemon(seen.Name)
+	if err != nil {
+		return err
+	}
+
+	output := seenOutput{Name: seen.Name, Types: []string{}, SeenAt: seen.SeenAt, SeenOn: seen.SeenOn}
+	for _, pokemonType := range pokemon.Types {
+		output.Types = append(output.Types, pokemonType.Type.Name)
+	}
+
+	return emit(output, func() {
+		seenOn := seen.SeenOn.Format(time.DateOnly)
+		if seen.SeenAt != "" {
+			seenOn = fmt.Sprintf("%v on %v", seen.SeenAt, seenOn)
+		}
+		types := make([]string, 0, len(output.Types))
+		for _, name := range output.Types {
+			types = append(types, renderer.Type(name))
+		}
+
+		fmt.Printf("%v %v\n", renderer.Bold(pokemonName(seen.Name)), renderer.Dim("(seen)"))
+		renderer.Table(os.Stdout, nil, [][]string{
+			{"Types:", strings.Join(types, " ")},
+			{"Seen:", seenOn},
+		})
+		fmt.Printf("Catch %s to learn more about it.\n", pokemonName(seen.Name))
+	})
+}
+
+// seenCount returns the number of species seen and caught
+func seenCount() (seen int, caught int) {
+	for _, s := range currentTrainer.Seen {
+		if currentTrainer.HasCaught(s.Name) {
+			caught++
+		}
+	}
+	return len(currentTrainer.Seen), caught
+}
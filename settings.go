@@ -0,0 +1,241 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/config"
+	"github.com/rkanagy/pokedexcli/internal/output"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+// the names of the settings, which are also the names of the program flags
+// that override them
+const (
+	cacheIntervalSetting = "cache-interval"
+	pageSizeSetting      = "page-size"
+	baseURLSetting       = "base-url"
+	outputSetting        = "output"
+	languageSetting      = "language"
+	workersSetting       = "workers"
+	rateLimitSetting     = "rate-limit"
+)
+
+// apiSettings are only read when the Pokemon API is created at startup
+var apiSettings = map[string]bool{cacheIntervalSetting: true, pageSizeSetting: true, baseURLSetting: true, workersSetting: true, rateLimitSetting: true}
+
+var configActions = []string{"list", "get", "set", "unset"}
+
+var settings *config.Config = newSettings()
+
+// configFilePath returns the path of a file in the pokedexcli directory of
+// the user's config directory
+func configFilePath(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "pokedexcli", name)
+}
+
+func newSettings() *config.Config {
+	return config.New(configFilePath("config.json"), []config.Setting{
+		{
+			Name:    cacheIntervalSetting,
+			Default: pokemon.DefaultCacheInterval.String(),
+			Usage:   "how long API responses are cached, such as 30s or 10m",
+			Validate: func(value string) error {
+				interval, err := time.ParseDuration(value)
+				if err == nil && interval <= 0 {
+					err = errors.New("must be positive")
+				}
+				return err
+			},
+		},
+		{
+			Name:    pageSizeSetting,
+			Default: strconv.Itoa(pokemon.DefaultPageSize),
+			Usage:   "the number of location areas map and mapb list at a time",
+			Validate: func(value string) error {
+				size, err := strconv.Atoi(value)
+				if err == nil && size <= 0 {
+					err = errors.New("must be positive")
+				}
+				return err
+			},
+		},
+		{
+			Name:    workersSetting,
+			Default: strconv.Itoa(pokemon.DefaultWorkers),
+			Usage:   "the number of API requests made at once by commands that need many",
+			Validate: func(value string) error {
+				workers, err := strconv.Atoi(value)
+				if err == nil && workers <= 0 {
+					err = errors.New("must be positive")
+				}
+				return err
+			},
+		},
+		{
+			Name:    rateLimitSetting,
+			Default: "0",
+			Usage:   "the most API requests started each second, 0 for no limit",
+			Validate: func(value string) error {
+				limit, err := strconv.Atoi(value)
+				if err == nil && limit < 0 {
+					err = errors.New("must not be negative")
+				}
+				return err
+			},
+		},
+		{
+			Name:    baseURLSetting,
+			Default: pokemon.DefaultBaseURL,
+			Usage:   "the address of the PokeAPI server",
+			Validate: func(value string) error {
+				address, err := url.Parse(value)
+				if err == nil && (address.Scheme != "http" && address.Scheme != "https" || address.Host == "") {
+					err = errors.New("must be an http or https URL")
+				}
+				return err
+			},
+		},
+		{
+			Name:    outputSetting,
+			Default: string(output.Text),
+			Usage:   "the format commands with listings, such as map, pokedex and where, write them in",
+			Validate: func(value string) error {
+				_, err := output.ParseFormat(value)
+				return err
+			},
+		},
+		{
+			Name:    languageSetting,
+			Default: pokemon.English,
+			Usage:   "the language Pokemon and location area names are displayed in, falling back to English",
+			Validate: func(value string) error {
+				for _, code := range pokemon.Languages {
+					if code == value {
+						return nil
+					}
+				}
+				return fmt.Errorf("must be one of %s", strings.Join(pokemon.Languages, ", "))
+			},
+		},
+	})
+}
+
+// applySettings creates the Pokemon API and sets the output format and
+// language from the current settings
+func applySettings() {
+	interval, _ := time.ParseDuration(settings.Value(cacheIntervalSetting))
+	pageSize, _ := strconv.Atoi(settings.Value(pageSizeSetting))
+	workers, _ := strconv.Atoi(settings.Value(workersSetting))
+	rateLimit, _ := strconv.Atoi(settings.Value(rateLimitSetting))
+	pokemonAPI = pokemon.NewAPI(pokemon.Options{
+		BaseURL:       settings.Value(baseURLSetting),
+		CacheInterval: interval,
+		PageSize:      pageSize,
+		Workers:       workers,
+		RateLimit:     rateLimit,
+	})
+
+	applyDisplaySettings()
+}
+
+// applyDisplaySettings sets the output format and language, which unlike
+// the API settings can change while pokedexcli runs
+func applyDisplaySettings() {
+	outputFormat, _ = output.ParseFormat(settings.Value(outputSetting))
+	language = settings.Value(languageSetting)
+}
+
+func commandConfig(args cli.Args) error {
+	name := args.String("name")
+	switch args.String("action") {
+	case "list":
+		return listSettings()
+	case "get":
+		if name == "" {
+			return errors.New("config get needs the name of a setting")
+		}
+		value, source, err := settings.Get(name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s (from %s)\n", value, source)
+	case "set":
+		if name == "" || !args.IsSet("value") {
+			return errors.New("config set needs the name and value of a setting")
+		}
+		if err := settings.Set(name, args.String("value")); err != nil {
+			return err
+		}
+		reportSettingChange(name)
+	case "unset":
+		if name == "" {
+			return errors.New("config unset needs the name of a setting")
+		}
+		if err := settings.Unset(name); err != nil {
+			return err
+		}
+		reportSettingChange(name)
+	default:
+		return fmt.Errorf("unknown config action %q, expected one of %s", args.String("action"), strings.Join(configActions, ", "))
+	}
+
+	return nil
+}
+
+// reportSettingChange applies a change to the config file and explains when
+// it does not take effect right away
+func reportSettingChange(name string) {
+	_, source, _ := settings.Get(name)
+	switch {
+	case source == config.Env:
+		fmt.Printf("Saved, but %s overrides the config file\n", config.EnvName(name))
+	case source == config.Flag:
+		fmt.Printf("Saved, but the --%s flag overrides the config file\n", name)
+	case apiSettings[name]:
+		fmt.Println("Saved, this takes effect the next time pokedexcli starts")
+	default:
+		applyDisplaySettings()
+	}
+}
+
+func listSettings() error {
+	rows := [][]string{}
+	for _, setting := range settings.Settings() {
+		value, source, err := settings.Get(setting.Name)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, []string{setting.Name, value, string(source), config.EnvName(setting.Name)})
+	}
+
+	renderer.Table(os.Stdout, []string{"Setting", "Value", "Source", "Environment"}, rows)
+	fmt.Printf("\nConfig file: %s\n", settings.Path())
+	return nil
+}
+
+// completeConfig completes the config actions and setting names
+func completeConfig(argIndex int) []string {
+	switch argIndex {
+	case 0:
+		return configActions
+	case 1:
+		names := []string{}
+		for _, setting := range settings.Settings() {
+			names = append(names, setting.Name)
+		}
+		return names
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+)
+
+func commandSprite(args cli.Args) error {
+	var species pokemon.Pokemon
+	if caught, err := currentTrainer.Find(args.String("pokemon")); err == nil {
+		species = caught.Species
+	} else {
+		species, err = pokemonAPI.GetPokemon(strings.ToLower(args.String("pokemon")))
+		if err != nil {
+			return err
+		}
+	}
+
+	sprite, err := pokemonAPI.GetSprite(species.Sprites.SpriteURL(args.Bool("shiny"), args.Bool("back")))
+	if err != nil {
+		return err
+	}
+
+	renderer.Image(os.Stdout, sprite)
+	return nil
+}
+
+// completeSprite completes your Pokemon and the Pokemon from the last
+// explore
+func completeSprite(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	return append(completeCaughtPokemon(argIndex), lastExploredPokemon...)
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/trainer"
+	"github.com/rkanagy/pokedexcli/internal/world"
+)
+
+// trainerFile is the file the trainer is saved to after each command,
+// empty when it could not be loaded so a broken save is not overwritten
+var trainerFile string
+
+// savedTrainer is the trainer as last saved, so unchanged trainers are not
+// saved again
+var savedTrainer []byte
+
+func trainerFilePath() string {
+	return configFilePath("trainer.json")
+}
+
+// loadTrainer makes the trainer saved in the file the current one
+func loadTrainer(path string) {
+	loaded, err := trainer.Load(path)
+	if err != nil {
+		errorHandler(fmt.Errorf("%v, your progress will not be saved", err))
+		return
+	}
+	currentTrainer = loaded
+	trainerFile = path
+	savedTrainer, _ = json.Marshal(currentTrainer)
+}
+
+// saveTrainer saves the current trainer if it changed since it was loaded
+// or last saved
+func saveTrainer() error {
+	if trainerFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(currentTrainer)
+	if err != nil || bytes.Equal(data, savedTrainer) {
+		return err
+	}
+	if err := currentTrainer.Save(trainerFile); err != nil {
+		return err
+	}
+	savedTrainer = data
+	return nil
+}
+
+// lookPokemon is a Pokemon that can be encountered where the trainer is
+type lookPokemon struct {
+	Name     string `json:"name"`
+	MinLevel int    `json:"min_level"`
+	MaxLevel int    `json:"max_level"`
+	Seen     bool   `json:"seen"`
+	Caught   bool   `json:"caught"`
+}
+
+// lookOutput is the location area the trainer is in as written by look and
+// travel
+type lookOutput struct {
+	LocationArea string        `json:"location_area"`
+	Location     string        `json:"location"`
+	Region       string        `json:"region,omitempty"`
+	Pokemon      []lookPokemon `json:"pokemon"`
+	Neighbors    []string      `json:"neighbors"`
+}
+
+// Table implements output.Tabular with a row per Pokemon and per
+// neighboring location area
+func (l lookOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(l.Pokemon)+len(l.Neighbors))
+	for _, p := range l.Pokemon {
+		rows = append(rows, []string{l.LocationArea, "pokemon", p.Name})
+	}
+	for _, neighbor := range l.Neighbors {
+		rows = append(rows, []string{l.LocationArea, "neighbor", neighbor})
+	}
+	return []string{"location_area", "kind", "name"}, rows
+}
+
+func commandLook(args cli.Args) error {
+	if currentTrainer.Location == "" {
+		return errors.New("You have not started your journey yet, travel to a location area listed by map")
+	}
+
+	look, err := newLookOutput(currentTrainer.Location)
+	if err != nil {
+		return err
+	}
+	return emit(look, func() {
+		displayLook(look)
+	})
+}
+
+func commandTravel(args cli.Args) error {
+	destination, err := resolveName("location area", strings.Join(args.List("location-area"), " "), pokemonAPI.GetLocationAreaNames)
+	if err != nil {
+		return err
+	}
+	if destination == currentTrainer.Location {
+		return fmt.Errorf("You are already at %s", destination)
+	}
+
+	if currentTrainer.Location != "" {
+		neighbors, err := world.Neighbors(&pokemonAPI, currentTrainer.Location)
+		if err != nil {
+			return err
+		}
+		if !contains(neighbors, destination) {
+			if len(neighbors) == 0 {
+				return fmt.Errorf("%s cannot be reached from %s, there is nowhere to travel to from here", destination, currentTrainer.Location)
+			}
+			return fmt.Errorf("%s cannot be reached from %s, try one of %s", destination, currentTrainer.Location, strings.Join(neighbors, ", "))
+		}
+	}
+
+	look, err := newLookOutput(destination)
+	if err != nil {
+		return err
+	}
+	currentTrainer.Location = destination
+	seenLocationAreas[destination] = true
+
+	return emit(look, func() {
+		fmt.Printf("Travelling to %s...\n", locationAreaName(destination))
+		displayLook(look)
+	})
+}
+
+// newLookOutput describes the location area, its Pokemon and where the
+// trainer can travel from it
+func newLookOutput(locationArea string) (lookOutput, error) {
+	area, err := pokemonAPI.GetLocationArea(locationArea)
+	if err != nil {
+		return lookOutput{}, err
+	}
+	location, err := pokemonAPI.GetLocation(area.Location.Name)
+	if err != nil {
+		return lookOutput{}, err
+	}
+	neighbors, err := world.Neighbors(&pokemonAPI, locationArea)
+	if err != nil {
+		return lookOutput{}, err
+	}
+
+	look := lookOutput{
+		LocationArea: area.Name,
+		Location:     location.Name,
+		Region:       location.Region.Name,
+		Pokemon:      []lookPokemon{},
+		Neighbors:    neighbors,
+	}
+	for _, encounter := range area.PokemonEncounters {
+		minLevel, maxLevel := encounterLevels(encounter)
+		name := encounter.Pokemon.Name
+		look.Pokemon = append(look.Pokemon, lookPokemon{
+			Name:     name,
+			MinLevel: minLevel,
+			MaxLevel: maxLevel,
+			Seen:     currentTrainer.HasSeen(name),
+			Caught:   currentTrainer.HasCaught(name),
+		})
+	}
+	return look, nil
+}
+
+func displayLook(look lookOutput) {
+	where := look.Location
+	if look.Region != "" {
+		where += " in " + look.Region
+	}
+	fmt.Printf("%v %v\n", renderer.Bold(locationAreaName(look.LocationArea)), renderer.Dim("("+where+")"))
+
+	names := make([]string, 0, len(look.Pokemon))
+	for _, p := range look.Pokemon {
+		names = append(names, p.Name)
+	}
+	fetchPokemonNames(names)
+	fetchLocationAreaNames(look.Neighbors)
+	if len(look.Pokemon) == 0 {
+		fmt.Println("There are no wild Pokemon here.")
+	} else {
+		rows := make([][]string, 0, len(look.Pokemon))
+		for _, p := range look.Pokemon {
+			levels := strconv.Itoa(p.MinLevel)
+			if p.MaxLevel != p.MinLevel {
+				levels += "-" + strconv.Itoa(p.MaxLevel)
+			}
+			status := ""
+			if p.Caught {
+				status = "caught"
+			} else if p.Seen {
+				status = "seen"
+			}
+			rows = append(rows, []string{pokemonName(p.Name), levels, renderer.Dim(status)})
+		}
+		renderer.Table(os.Stdout, []string{"Wild Pokemon", "Levels", ""}, rows)
+	}
+
+	if len(look.Neighbors) == 0 {
+		fmt.Println("There is nowhere to travel to from here.")
+		return
+	}
+	fmt.Println("You can travel to:")
+	for _, neighbor := range look.Neighbors {
+		fmt.Printf(" - %s\n", locationAreaName(neighbor))
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// completeTravel completes the location areas that can be travelled to,
+// or every known location area before the journey starts
+func completeTravel(argIndex int) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	if currentTrainer.Location == "" {
+		return completeLocationAreas(argIndex)
+	}
+	neighbors, err := world.Neighbors(&pokemonAPI, currentTrainer.Location)
+	if err != nil {
+		return nil
+	}
+	return neighbors
+}
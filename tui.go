@@ -0,0 +1,323 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+	"github.com/rkanagy/pokedexcli/internal/pokemon"
+	"github.com/rkanagy/pokedexcli/internal/term"
+	"github.com/rkanagy/pokedexcli/internal/tui"
+)
+
+// the panes of the tui, from left to right
+const (
+	locationsPane = iota
+	encountersPane
+	detailsPane
+)
+
+const tuiHelp = "↑/↓ move  enter select  ←/→ switch pane  n/p next/previous page  c catch  q quit"
+
+// defaultScreenHeight is used when the terminal size cannot be detected
+const defaultScreenHeight = 24
+
+// tuiState is the state of the full-screen interface
+type tuiState struct {
+	focus      int
+	offsets    []int // where the current and earlier pages of locations start
+	hasNext    bool
+	locations  tui.List
+	encounters tui.List
+	area       string
+	names      []string
+	details    *pokemon.Pokemon
+	status     string
+}
+
+func commandTUI(args cli.Args) error {
+	// keys are read through the line editor so no input it has buffered
+	// is lost
+	inFd, outFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !lineEditor.IsTerminal() || !term.IsTerminal(outFd) {
+		return errors.New("The tui can only run in a terminal")
+	}
+
+	restore, err := term.MakeRaw(inFd)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	// switch to the alternate screen and hide the cursor while running
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	ui := &tuiState{}
+	ui.loadPage(pokemon.Next)
+	for {
+		ui.draw()
+
+		k, r, err := lineEditor.ReadKey()
+		if err != nil {
+			return err
+		}
+		if !ui.handleKey(k, r) {
+			return nil
+		}
+	}
+}
+
+// handleKey acts on a key press and returns false when the user quits
+func (ui *tuiState) handleKey(k term.Key, r rune) bool {
+	ui.status = ""
+
+	switch {
+	case k == term.KeyCtrlC, k == term.KeyCtrlD, k == term.KeyRune && r == 'q':
+		return false
+	case k == term.KeyUp, k == term.KeyRune && r == 'k':
+		ui.move(-1)
+	case k == term.KeyDown, k == term.KeyRune && r == 'j':
+		ui.move(1)
+	case k == term.KeyHome:
+		ui.move(-len(ui.focusedList().Items))
+	case k == term.KeyEnd:
+		ui.move(len(ui.focusedList().Items))
+	case k == term.KeyPageDown, k == term.KeyRune && r == 'n':
+		ui.loadPage(pokemon.Next)
+	case k == term.KeyPageUp, k == term.KeyRune && r == 'p':
+		ui.loadPage(pokemon.Previous)
+	case k == term.KeyLeft, k == term.KeyEscape, k == term.KeyRune && r == 'h':
+		ui.focus = max(ui.focus-1, locationsPane)
+	case k == term.KeyTab:
+		ui.focus = (ui.focus + 1) % (detailsPane + 1)
+	case k == term.KeyEnter, k == term.KeyRight, k == term.KeyRune && r == 'l':
+		ui.selectFocused()
+	case k == term.KeyRune && r == 'c':
+		ui.catchSelected()
+	case k == term.KeyCtrlL:
+		fmt.Print("\x1b[2J")
+	}
+
+	return true
+}
+
+// focusedList returns the list moved by the arrow keys.  The details pane
+// moves through the encounters so the next Pokemon can be selected
+// without leaving it.
+func (ui *tuiState) focusedList() *tui.List {
+	if ui.focus == locationsPane {
+		return &ui.locations
+	}
+	return &ui.encounters
+}
+
+// move moves the selection of the focused list, showing the newly selected
+// Pokemon right away when the details pane is focused
+func (ui *tuiState) move(delta int) {
+	ui.focusedList().Move(delta)
+	if ui.focus == detailsPane {
+		ui.selectFocused()
+	}
+}
+
+// loadPage shows the next or previous page of location areas.  The tui
+// keeps its own place in the list so the map and mapb commands carry on
+// from where they were.
+func (ui *tuiState) loadPage(direction int) {
+	offsets := ui.offsets
+	switch {
+	case direction == pokemon.Next && len(offsets) == 0:
+		offsets = []int{0}
+	case direction == pokemon.Next && !ui.hasNext:
+		ui.status = "At bottom of locations list"
+		return
+	case direction == pokemon.Next:
+		offsets = append(offsets, offsets[len(offsets)-1]+len(ui.locations.Items))
+	case len(offsets) <= 1:
+		ui.status = "At top of locations list"
+		return
+	default:
+		offsets = offsets[:len(offsets)-1]
+	}
+
+	locations, err := pokemonAPI.GetLocationAreaPage(offsets[len(offsets)-1])
+	if err != nil {
+		ui.status = err.Error()
+		return
+	}
+	ui.offsets = offsets
+	ui.hasNext = locations.Next != nil
+
+	names := make([]string, 0, len(locations.Results))
+	for _, result := range locations.Results {
+		names = append(names, result.Name)
+	}
+	ui.locations.SetItems(names)
+	ui.focus = locationsPane
+}
+
+// selectFocused opens the selected location area or Pokemon in the next
+// pane
+func (ui *tuiState) selectFocused() {
+	switch ui.focus {
+	case locationsPane:
+		area := ui.locations.Current()
+		if area == "" {
+			return
+		}
+		location, err := exploreLocationArea(area)
+		if err != nil {
+			ui.status = err.Error()
+			return
+		}
+
+		ui.area = area
+		ui.names = append([]string{}, lastExploredPokemon...)
+		ui.encounters.SetItems(ui.encounterItems())
+		ui.details = nil
+		ui.focus = encountersPane
+		if len(location.PokemonEncounters) == 0 {
+			ui.status = fmt.Sprintf("There are no wild Pokemon at %s", area)
+		}
+	case encountersPane, detailsPane:
+		if len(ui.names) == 0 {
+			return
+		}
+		details, err := pokemonAPI.GetPokemon(ui.names[ui.encounters.Selected])
+		if err != nil {
+			ui.status = err.Error()
+			return
+		}
+		ui.details = &details
+		ui.focus = detailsPane
+	}
+}
+
+func (ui *tuiState) catchSelected() {
+	if ui.focus == locationsPane || len(ui.names) == 0 {
+		return
+	}
+
+	name := ui.names[ui.encounters.Selected]
+	caught, boxed, err := catchPokemon(name)
+	switch {
+	case err != nil:
+		ui.status = err.Error()
+	case caught == nil:
+		ui.status = fmt.Sprintf("%s escaped!", name)
+	case boxed:
+		ui.status = fmt.Sprintf("%s was caught at level %d and sent to a box! (ID #%d)", name, caught.Level, caught.ID)
+	default:
+		ui.status = fmt.Sprintf("%s was caught at level %d! (ID #%d)", name, caught.Level, caught.ID)
+	}
+
+	selected := ui.encounters.Selected
+	ui.encounters.Items = ui.encounterItems()
+	ui.encounters.Selected = selected
+}
+
+// encounterItems returns the names of the Pokemon at the location area,
+// marking the ones already caught
+func (ui *tuiState) encounterItems() []string {
+	items := make([]string, 0, len(ui.names))
+	for _, name := range ui.names {
+		if currentTrainer.HasCaught(name) {
+			name += " *"
+		}
+		items = append(items, name)
+	}
+	return items
+}
+
+func (ui *tuiState) draw() {
+	width, height, err := term.Size(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = renderer.Width, defaultScreenHeight
+	}
+
+	encountersTitle := "Pokemon"
+	if ui.area != "" {
+		encountersTitle = "Pokemon at " + ui.area
+	}
+	panes := []tui.Pane{
+		{Title: "Location areas", Weight: 2},
+		{Title: encountersTitle, Weight: 2},
+		{Title: "Details", Weight: 3},
+	}
+	panes[ui.focus].Focused = true
+
+	innerWidths, innerHeight := tui.InnerSize(panes, width, height)
+	highlight := func(text string) string { return text }
+	if renderer.Color {
+		highlight = renderer.Reverse
+	}
+	panes[locationsPane].Lines = ui.locations.Lines(innerHeight, highlight)
+	panes[encountersPane].Lines = ui.encounters.Lines(innerHeight, highlight)
+	panes[detailsPane].Lines = ui.detailLines(innerWidths[detailsPane])
+
+	// errors from the API can span several lines
+	status, _, _ := strings.Cut(ui.status, "\n")
+	if status == "" {
+		status = renderer.Dim(tuiHelp)
+	}
+
+	fmt.Print("\x1b[H")
+	lines := tui.Frame(renderer, panes, width, height, status)
+	for i, line := range lines {
+		fmt.Print(line + "\x1b[K")
+		if i < len(lines)-1 {
+			fmt.Print("\r\n")
+		}
+	}
+}
+
+// detailLines describes the Pokemon shown in the details pane, with base
+// stat bars filling the width of the pane
+func (ui *tuiState) detailLines(width int) []string {
+	if ui.details == nil {
+		return []string{"Select a Pokemon to see its details"}
+	}
+	p := ui.details
+
+	types := make([]string, 0, len(p.Types))
+	for _, pokemonType := range p.Types {
+		types = append(types, renderer.Type(pokemonType.Type.Name))
+	}
+	caught := "no"
+	if currentTrainer.HasCaught(p.Name) {
+		caught = "yes"
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s %s", renderer.Bold(p.Name), renderer.Dim(fmt.Sprintf("#%d", p.ID))),
+		"Types:  " + strings.Join(types, " "),
+		fmt.Sprintf("Height: %d  Weight: %d", p.Height, p.Weight),
+		fmt.Sprintf("Base experience: %d", p.BaseExperience),
+		"Caught: " + caught,
+		"",
+	}
+
+	const statLabelWidth = 16
+	barWidth := min(width-statLabelWidth-5, maxStatBarWidth)
+	for _, stat := range p.Stats {
+		line := fmt.Sprintf("%-*s %3d", statLabelWidth, stat.Stat.Name, stat.BaseStat)
+		if barWidth > 0 {
+			line += " " + renderer.StatBar(stat.BaseStat, barWidth)
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", "Abilities:")
+	for _, pokemonAbility := range p.Abilities {
+		if pokemonAbility.IsHidden {
+			lines = append(lines, "  "+pokemonAbility.Ability.Name+renderer.Dim(" (hidden)"))
+		} else {
+			lines = append(lines, "  "+pokemonAbility.Ability.Name)
+		}
+	}
+
+	return lines
+}
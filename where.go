@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rkanagy/pokedexcli/internal/cli"
+)
+
+// whereEntry is how a Pokemon can be encountered at a location area in one
+// version with one method, combining the encounter slots of that method
+type whereEntry struct {
+	LocationArea string `json:"location_area"`
+	Version      string `json:"version"`
+	Method       string `json:"method"`
+	MinLevel     int    `json:"min_level"`
+	MaxLevel     int    `json:"max_level"`
+	Chance       int    `json:"chance"`
+}
+
+// whereOutput lists where a Pokemon can be encountered, as written by where
+type whereOutput []whereEntry
+
+// Table implements output.Tabular
+func (w whereOutput) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(w))
+	for _, entry := range w {
+		rows = append(rows, []string{entry.LocationArea, entry.Version, entry.Method, strconv.Itoa(entry.MinLevel), strconv.Itoa(entry.MaxLevel), strconv.Itoa(entry.Chance)})
+	}
+	return []string{"location_area", "version", "method", "min_level", "max_level", "chance"}, rows
+}
+
+func commandWhere(args cli.Args) error {
+	name := strings.ToLower(args.String("pokemon"))
+	if caught, err := currentTrainer.Find(args.String("pokemon")); err == nil {
+		name = caught.Species.Name
+	}
+	version := args.String("version")
+
+	encounters, err := pokemonAPI.GetPokemonEncounters(name)
+	if err != nil {
+		return err
+	}
+
+	entries := whereOutput{}
+	index := make(map[string]int)
+	versions := make(map[string]bool)
+	for _, encounter := range encounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			versions[versionDetail.Version.Name] = true
+			if version != "" && versionDetail.Version.Name != version {
+				continue
+			}
+
+			for _, detail := range versionDetail.EncounterDetails {
+				key := encounter.LocationArea.Name + "/" + versionDetail.Version.Name + "/" + detail.Method.Name
+				i, found := index[key]
+				if !found {
+					i = len(entries)
+					index[key] = i
+					entries = append(entries, whereEntry{
+						LocationArea: encounter.LocationArea.Name,
+						Version:      versionDetail.Version.Name,
+						Method:       detail.Method.Name,
+						MinLevel:     detail.MinLevel,
+						MaxLevel:     detail.MaxLevel,
+					})
+				}
+				entries[i].MinLevel = min(entries[i].MinLevel, detail.MinLevel)
+				entries[i].MaxLevel = max(entries[i].MaxLevel, detail.MaxLevel)
+				entries[i].Chance = min(entries[i].Chance+detail.Chance, 100)
+			}
+		}
+	}
+
+	if len(encounters) == 0 {
+		return fmt.Errorf("%s cannot be found in the wild", name)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("%s cannot be found in the wild in %s, try one of %s", name, version, strings.Join(sortedSet(versions), ", "))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.LocationArea != b.LocationArea {
+			return a.LocationArea < b.LocationArea
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.Method < b.Method
+	})
+
+	return emit(entries, func() {
+		fmt.Printf("%s can be found at:\n", name)
+		rows := make([][]string, 0, len(entries))
+		for _, entry := range entries {
+			levels := strconv.Itoa(entry.MinLevel)
+			if entry.MaxLevel != entry.MinLevel {
+				levels += "-" + strconv.Itoa(entry.MaxLevel)
+			}
+			rows = append(rows, []string{entry.LocationArea, entry.Version, entry.Method, levels, strconv.Itoa(entry.Chance) + "%"})
+		}
+		renderer.Table(os.Stdout, []string{"Location area", "Version", "Method", "Levels", "Chance"}, rows)
+	})
+}
+
+func sortedSet(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}